@@ -0,0 +1,817 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: news/v1/service.proto
+
+package newsv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// NewsServiceName is the fully-qualified name of the NewsService service.
+	NewsServiceName = "news.v1.NewsService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// NewsServiceCreateProcedure is the fully-qualified name of the NewsService's Create RPC.
+	NewsServiceCreateProcedure = "/news.v1.NewsService/Create"
+	// NewsServiceGetProcedure is the fully-qualified name of the NewsService's Get RPC.
+	NewsServiceGetProcedure = "/news.v1.NewsService/Get"
+	// NewsServiceBatchGetNewsProcedure is the fully-qualified name of the NewsService's BatchGetNews
+	// RPC.
+	NewsServiceBatchGetNewsProcedure = "/news.v1.NewsService/BatchGetNews"
+	// NewsServiceGetAllProcedure is the fully-qualified name of the NewsService's GetAll RPC.
+	NewsServiceGetAllProcedure = "/news.v1.NewsService/GetAll"
+	// NewsServiceWatchNewsProcedure is the fully-qualified name of the NewsService's WatchNews RPC.
+	NewsServiceWatchNewsProcedure = "/news.v1.NewsService/WatchNews"
+	// NewsServiceListChangesProcedure is the fully-qualified name of the NewsService's ListChanges RPC.
+	NewsServiceListChangesProcedure = "/news.v1.NewsService/ListChanges"
+	// NewsServiceListNewsProcedure is the fully-qualified name of the NewsService's ListNews RPC.
+	NewsServiceListNewsProcedure = "/news.v1.NewsService/ListNews"
+	// NewsServiceSearchNewsProcedure is the fully-qualified name of the NewsService's SearchNews RPC.
+	NewsServiceSearchNewsProcedure = "/news.v1.NewsService/SearchNews"
+	// NewsServiceListRevisionsProcedure is the fully-qualified name of the NewsService's ListRevisions
+	// RPC.
+	NewsServiceListRevisionsProcedure = "/news.v1.NewsService/ListRevisions"
+	// NewsServiceGetRevisionProcedure is the fully-qualified name of the NewsService's GetRevision RPC.
+	NewsServiceGetRevisionProcedure = "/news.v1.NewsService/GetRevision"
+	// NewsServiceDiffRevisionsProcedure is the fully-qualified name of the NewsService's DiffRevisions
+	// RPC.
+	NewsServiceDiffRevisionsProcedure = "/news.v1.NewsService/DiffRevisions"
+	// NewsServiceRollbackNewsProcedure is the fully-qualified name of the NewsService's RollbackNews
+	// RPC.
+	NewsServiceRollbackNewsProcedure = "/news.v1.NewsService/RollbackNews"
+	// NewsServiceUpdateProcedure is the fully-qualified name of the NewsService's Update RPC.
+	NewsServiceUpdateProcedure = "/news.v1.NewsService/Update"
+	// NewsServiceUpdateNewsProcedure is the fully-qualified name of the NewsService's UpdateNews RPC.
+	NewsServiceUpdateNewsProcedure = "/news.v1.NewsService/UpdateNews"
+	// NewsServiceDeletedNewsProcedure is the fully-qualified name of the NewsService's DeletedNews RPC.
+	NewsServiceDeletedNewsProcedure = "/news.v1.NewsService/DeletedNews"
+	// NewsServiceSubmitNewsProcedure is the fully-qualified name of the NewsService's SubmitNews RPC.
+	NewsServiceSubmitNewsProcedure = "/news.v1.NewsService/SubmitNews"
+	// NewsServiceApproveNewsProcedure is the fully-qualified name of the NewsService's ApproveNews RPC.
+	NewsServiceApproveNewsProcedure = "/news.v1.NewsService/ApproveNews"
+	// NewsServiceRejectNewsProcedure is the fully-qualified name of the NewsService's RejectNews RPC.
+	NewsServiceRejectNewsProcedure = "/news.v1.NewsService/RejectNews"
+	// NewsServicePublishNewsProcedure is the fully-qualified name of the NewsService's PublishNews RPC.
+	NewsServicePublishNewsProcedure = "/news.v1.NewsService/PublishNews"
+	// NewsServiceArchiveNewsProcedure is the fully-qualified name of the NewsService's ArchiveNews RPC.
+	NewsServiceArchiveNewsProcedure = "/news.v1.NewsService/ArchiveNews"
+	// NewsServiceListTrashProcedure is the fully-qualified name of the NewsService's ListTrash RPC.
+	NewsServiceListTrashProcedure = "/news.v1.NewsService/ListTrash"
+	// NewsServiceRestoreNewsProcedure is the fully-qualified name of the NewsService's RestoreNews RPC.
+	NewsServiceRestoreNewsProcedure = "/news.v1.NewsService/RestoreNews"
+	// NewsServicePurgeNewsProcedure is the fully-qualified name of the NewsService's PurgeNews RPC.
+	NewsServicePurgeNewsProcedure = "/news.v1.NewsService/PurgeNews"
+	// NewsServiceEmptyTrashProcedure is the fully-qualified name of the NewsService's EmptyTrash RPC.
+	NewsServiceEmptyTrashProcedure = "/news.v1.NewsService/EmptyTrash"
+)
+
+// NewsServiceClient is a client for the news.v1.NewsService service.
+type NewsServiceClient interface {
+	// Creates the news under the id of the request. Retrying with the same id
+	// and content returns the stored news, a different content fails with
+	// ALREADY_EXISTS.
+	Create(context.Context, *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error)
+	// News under embargo are not found unless the caller is privileged
+	Get(context.Context, *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error)
+	// Gets up to 100 news at once from a consistent view, reporting every id
+	// that is invalid, unknown or deleted in its result
+	BatchGetNews(context.Context, *connect.Request[v1.BatchGetNewsRequest]) (*connect.Response[v1.BatchGetNewsResponse], error)
+	// Server side stream of the published news, newline-delimited JSON over
+	// HTTP
+	GetAll(context.Context, *connect.Request[emptypb.Empty]) (*connect.ServerStreamForClient[v1.GetAllResponse], error)
+	// Server side stream of the changes of the news as they are written
+	WatchNews(context.Context, *connect.Request[v1.WatchNewsRequest]) (*connect.ServerStreamForClient[v1.WatchNewsResponse], error)
+	// Changes of the news since a cursor in commit order, for incremental sync.
+	// Fails with OUT_OF_RANGE once news changed after the cursor were purged.
+	ListChanges(context.Context, *connect.Request[v1.ListChangesRequest]) (*connect.Response[v1.ListChangesResponse], error)
+	// Paginated and filterable listing of the news, news under embargo are
+	// left out unless the caller is privileged
+	ListNews(context.Context, *connect.Request[v1.ListNewsRequest]) (*connect.Response[v1.ListNewsResponse], error)
+	// Full-text search over the title, summary and content of the news
+	SearchNews(context.Context, *connect.Request[v1.SearchNewsRequest]) (*connect.Response[v1.SearchNewsResponse], error)
+	// Revisions of the news from the oldest to the latest
+	ListRevisions(context.Context, *connect.Request[v1.ListRevisionsRequest]) (*connect.Response[v1.ListRevisionsResponse], error)
+	GetRevision(context.Context, *connect.Request[v1.GetRevisionRequest]) (*connect.Response[v1.Revision], error)
+	// Field-level changes between two revisions of the news
+	DiffRevisions(context.Context, *connect.Request[v1.DiffRevisionsRequest]) (*connect.Response[v1.DiffRevisionsResponse], error)
+	// Restores the content and the schedule of a previous revision as a new
+	// revision
+	RollbackNews(context.Context, *connect.Request[v1.RollbackNewsRequest]) (*connect.Response[v1.News], error)
+	// Updates the fields of the update mask, the other fields are left as is
+	Update(context.Context, *connect.Request[v1.UpdateNewsRequest]) (*connect.Response[v1.News], error)
+	// Client side stream, reports the outcome of every streamed update
+	UpdateNews(context.Context) *connect.ClientStreamForClient[v1.UpdateNewsRequest, v1.UpdateNewsResponse]
+	// Bidirectional stream, acknowledges every streamed id with its outcome
+	DeletedNews(context.Context) *connect.BidiStreamForClient[v1.NewsID, v1.DeletedNewsResponse]
+	// Submits a draft for review
+	SubmitNews(context.Context, *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error)
+	// Publishes a news in review
+	ApproveNews(context.Context, *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error)
+	// Sends a news in review back to draft
+	RejectNews(context.Context, *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error)
+	// Publishes a draft without review, or an archived news again
+	PublishNews(context.Context, *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error)
+	// Withdraws a published news from the readers
+	ArchiveNews(context.Context, *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error)
+	// Soft deleted news in the order of their deletion
+	ListTrash(context.Context, *connect.Request[v1.ListTrashRequest]) (*connect.Response[v1.ListTrashResponse], error)
+	// Clears the deletion of a soft deleted news
+	RestoreNews(context.Context, *connect.Request[v1.RestoreNewsRequest]) (*connect.Response[v1.News], error)
+	// Permanently deletes soft deleted news along with their revisions
+	PurgeNews(context.Context, *connect.Request[v1.PurgeNewsRequest]) (*connect.Response[v1.PurgeNewsResponse], error)
+	// Permanently deletes the news soft deleted before a timestamp
+	EmptyTrash(context.Context, *connect.Request[v1.EmptyTrashRequest]) (*connect.Response[v1.EmptyTrashResponse], error)
+}
+
+// NewNewsServiceClient constructs a client for the news.v1.NewsService service. By default, it uses
+// the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewNewsServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) NewsServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	newsServiceMethods := v1.File_news_v1_service_proto.Services().ByName("NewsService").Methods()
+	return &newsServiceClient{
+		create: connect.NewClient[v1.CreateRequest, v1.CreateResponse](
+			httpClient,
+			baseURL+NewsServiceCreateProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("Create")),
+			connect.WithClientOptions(opts...),
+		),
+		get: connect.NewClient[v1.GetRequest, v1.GetResponse](
+			httpClient,
+			baseURL+NewsServiceGetProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("Get")),
+			connect.WithClientOptions(opts...),
+		),
+		batchGetNews: connect.NewClient[v1.BatchGetNewsRequest, v1.BatchGetNewsResponse](
+			httpClient,
+			baseURL+NewsServiceBatchGetNewsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("BatchGetNews")),
+			connect.WithClientOptions(opts...),
+		),
+		getAll: connect.NewClient[emptypb.Empty, v1.GetAllResponse](
+			httpClient,
+			baseURL+NewsServiceGetAllProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("GetAll")),
+			connect.WithClientOptions(opts...),
+		),
+		watchNews: connect.NewClient[v1.WatchNewsRequest, v1.WatchNewsResponse](
+			httpClient,
+			baseURL+NewsServiceWatchNewsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("WatchNews")),
+			connect.WithClientOptions(opts...),
+		),
+		listChanges: connect.NewClient[v1.ListChangesRequest, v1.ListChangesResponse](
+			httpClient,
+			baseURL+NewsServiceListChangesProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("ListChanges")),
+			connect.WithClientOptions(opts...),
+		),
+		listNews: connect.NewClient[v1.ListNewsRequest, v1.ListNewsResponse](
+			httpClient,
+			baseURL+NewsServiceListNewsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("ListNews")),
+			connect.WithClientOptions(opts...),
+		),
+		searchNews: connect.NewClient[v1.SearchNewsRequest, v1.SearchNewsResponse](
+			httpClient,
+			baseURL+NewsServiceSearchNewsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("SearchNews")),
+			connect.WithClientOptions(opts...),
+		),
+		listRevisions: connect.NewClient[v1.ListRevisionsRequest, v1.ListRevisionsResponse](
+			httpClient,
+			baseURL+NewsServiceListRevisionsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("ListRevisions")),
+			connect.WithClientOptions(opts...),
+		),
+		getRevision: connect.NewClient[v1.GetRevisionRequest, v1.Revision](
+			httpClient,
+			baseURL+NewsServiceGetRevisionProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("GetRevision")),
+			connect.WithClientOptions(opts...),
+		),
+		diffRevisions: connect.NewClient[v1.DiffRevisionsRequest, v1.DiffRevisionsResponse](
+			httpClient,
+			baseURL+NewsServiceDiffRevisionsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("DiffRevisions")),
+			connect.WithClientOptions(opts...),
+		),
+		rollbackNews: connect.NewClient[v1.RollbackNewsRequest, v1.News](
+			httpClient,
+			baseURL+NewsServiceRollbackNewsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("RollbackNews")),
+			connect.WithClientOptions(opts...),
+		),
+		update: connect.NewClient[v1.UpdateNewsRequest, v1.News](
+			httpClient,
+			baseURL+NewsServiceUpdateProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("Update")),
+			connect.WithClientOptions(opts...),
+		),
+		updateNews: connect.NewClient[v1.UpdateNewsRequest, v1.UpdateNewsResponse](
+			httpClient,
+			baseURL+NewsServiceUpdateNewsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("UpdateNews")),
+			connect.WithClientOptions(opts...),
+		),
+		deletedNews: connect.NewClient[v1.NewsID, v1.DeletedNewsResponse](
+			httpClient,
+			baseURL+NewsServiceDeletedNewsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("DeletedNews")),
+			connect.WithClientOptions(opts...),
+		),
+		submitNews: connect.NewClient[v1.TransitionNewsRequest, v1.News](
+			httpClient,
+			baseURL+NewsServiceSubmitNewsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("SubmitNews")),
+			connect.WithClientOptions(opts...),
+		),
+		approveNews: connect.NewClient[v1.TransitionNewsRequest, v1.News](
+			httpClient,
+			baseURL+NewsServiceApproveNewsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("ApproveNews")),
+			connect.WithClientOptions(opts...),
+		),
+		rejectNews: connect.NewClient[v1.TransitionNewsRequest, v1.News](
+			httpClient,
+			baseURL+NewsServiceRejectNewsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("RejectNews")),
+			connect.WithClientOptions(opts...),
+		),
+		publishNews: connect.NewClient[v1.TransitionNewsRequest, v1.News](
+			httpClient,
+			baseURL+NewsServicePublishNewsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("PublishNews")),
+			connect.WithClientOptions(opts...),
+		),
+		archiveNews: connect.NewClient[v1.TransitionNewsRequest, v1.News](
+			httpClient,
+			baseURL+NewsServiceArchiveNewsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("ArchiveNews")),
+			connect.WithClientOptions(opts...),
+		),
+		listTrash: connect.NewClient[v1.ListTrashRequest, v1.ListTrashResponse](
+			httpClient,
+			baseURL+NewsServiceListTrashProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("ListTrash")),
+			connect.WithClientOptions(opts...),
+		),
+		restoreNews: connect.NewClient[v1.RestoreNewsRequest, v1.News](
+			httpClient,
+			baseURL+NewsServiceRestoreNewsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("RestoreNews")),
+			connect.WithClientOptions(opts...),
+		),
+		purgeNews: connect.NewClient[v1.PurgeNewsRequest, v1.PurgeNewsResponse](
+			httpClient,
+			baseURL+NewsServicePurgeNewsProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("PurgeNews")),
+			connect.WithClientOptions(opts...),
+		),
+		emptyTrash: connect.NewClient[v1.EmptyTrashRequest, v1.EmptyTrashResponse](
+			httpClient,
+			baseURL+NewsServiceEmptyTrashProcedure,
+			connect.WithSchema(newsServiceMethods.ByName("EmptyTrash")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// newsServiceClient implements NewsServiceClient.
+type newsServiceClient struct {
+	create        *connect.Client[v1.CreateRequest, v1.CreateResponse]
+	get           *connect.Client[v1.GetRequest, v1.GetResponse]
+	batchGetNews  *connect.Client[v1.BatchGetNewsRequest, v1.BatchGetNewsResponse]
+	getAll        *connect.Client[emptypb.Empty, v1.GetAllResponse]
+	watchNews     *connect.Client[v1.WatchNewsRequest, v1.WatchNewsResponse]
+	listChanges   *connect.Client[v1.ListChangesRequest, v1.ListChangesResponse]
+	listNews      *connect.Client[v1.ListNewsRequest, v1.ListNewsResponse]
+	searchNews    *connect.Client[v1.SearchNewsRequest, v1.SearchNewsResponse]
+	listRevisions *connect.Client[v1.ListRevisionsRequest, v1.ListRevisionsResponse]
+	getRevision   *connect.Client[v1.GetRevisionRequest, v1.Revision]
+	diffRevisions *connect.Client[v1.DiffRevisionsRequest, v1.DiffRevisionsResponse]
+	rollbackNews  *connect.Client[v1.RollbackNewsRequest, v1.News]
+	update        *connect.Client[v1.UpdateNewsRequest, v1.News]
+	updateNews    *connect.Client[v1.UpdateNewsRequest, v1.UpdateNewsResponse]
+	deletedNews   *connect.Client[v1.NewsID, v1.DeletedNewsResponse]
+	submitNews    *connect.Client[v1.TransitionNewsRequest, v1.News]
+	approveNews   *connect.Client[v1.TransitionNewsRequest, v1.News]
+	rejectNews    *connect.Client[v1.TransitionNewsRequest, v1.News]
+	publishNews   *connect.Client[v1.TransitionNewsRequest, v1.News]
+	archiveNews   *connect.Client[v1.TransitionNewsRequest, v1.News]
+	listTrash     *connect.Client[v1.ListTrashRequest, v1.ListTrashResponse]
+	restoreNews   *connect.Client[v1.RestoreNewsRequest, v1.News]
+	purgeNews     *connect.Client[v1.PurgeNewsRequest, v1.PurgeNewsResponse]
+	emptyTrash    *connect.Client[v1.EmptyTrashRequest, v1.EmptyTrashResponse]
+}
+
+// Create calls news.v1.NewsService.Create.
+func (c *newsServiceClient) Create(ctx context.Context, req *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Get calls news.v1.NewsService.Get.
+func (c *newsServiceClient) Get(ctx context.Context, req *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// BatchGetNews calls news.v1.NewsService.BatchGetNews.
+func (c *newsServiceClient) BatchGetNews(ctx context.Context, req *connect.Request[v1.BatchGetNewsRequest]) (*connect.Response[v1.BatchGetNewsResponse], error) {
+	return c.batchGetNews.CallUnary(ctx, req)
+}
+
+// GetAll calls news.v1.NewsService.GetAll.
+func (c *newsServiceClient) GetAll(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.ServerStreamForClient[v1.GetAllResponse], error) {
+	return c.getAll.CallServerStream(ctx, req)
+}
+
+// WatchNews calls news.v1.NewsService.WatchNews.
+func (c *newsServiceClient) WatchNews(ctx context.Context, req *connect.Request[v1.WatchNewsRequest]) (*connect.ServerStreamForClient[v1.WatchNewsResponse], error) {
+	return c.watchNews.CallServerStream(ctx, req)
+}
+
+// ListChanges calls news.v1.NewsService.ListChanges.
+func (c *newsServiceClient) ListChanges(ctx context.Context, req *connect.Request[v1.ListChangesRequest]) (*connect.Response[v1.ListChangesResponse], error) {
+	return c.listChanges.CallUnary(ctx, req)
+}
+
+// ListNews calls news.v1.NewsService.ListNews.
+func (c *newsServiceClient) ListNews(ctx context.Context, req *connect.Request[v1.ListNewsRequest]) (*connect.Response[v1.ListNewsResponse], error) {
+	return c.listNews.CallUnary(ctx, req)
+}
+
+// SearchNews calls news.v1.NewsService.SearchNews.
+func (c *newsServiceClient) SearchNews(ctx context.Context, req *connect.Request[v1.SearchNewsRequest]) (*connect.Response[v1.SearchNewsResponse], error) {
+	return c.searchNews.CallUnary(ctx, req)
+}
+
+// ListRevisions calls news.v1.NewsService.ListRevisions.
+func (c *newsServiceClient) ListRevisions(ctx context.Context, req *connect.Request[v1.ListRevisionsRequest]) (*connect.Response[v1.ListRevisionsResponse], error) {
+	return c.listRevisions.CallUnary(ctx, req)
+}
+
+// GetRevision calls news.v1.NewsService.GetRevision.
+func (c *newsServiceClient) GetRevision(ctx context.Context, req *connect.Request[v1.GetRevisionRequest]) (*connect.Response[v1.Revision], error) {
+	return c.getRevision.CallUnary(ctx, req)
+}
+
+// DiffRevisions calls news.v1.NewsService.DiffRevisions.
+func (c *newsServiceClient) DiffRevisions(ctx context.Context, req *connect.Request[v1.DiffRevisionsRequest]) (*connect.Response[v1.DiffRevisionsResponse], error) {
+	return c.diffRevisions.CallUnary(ctx, req)
+}
+
+// RollbackNews calls news.v1.NewsService.RollbackNews.
+func (c *newsServiceClient) RollbackNews(ctx context.Context, req *connect.Request[v1.RollbackNewsRequest]) (*connect.Response[v1.News], error) {
+	return c.rollbackNews.CallUnary(ctx, req)
+}
+
+// Update calls news.v1.NewsService.Update.
+func (c *newsServiceClient) Update(ctx context.Context, req *connect.Request[v1.UpdateNewsRequest]) (*connect.Response[v1.News], error) {
+	return c.update.CallUnary(ctx, req)
+}
+
+// UpdateNews calls news.v1.NewsService.UpdateNews.
+func (c *newsServiceClient) UpdateNews(ctx context.Context) *connect.ClientStreamForClient[v1.UpdateNewsRequest, v1.UpdateNewsResponse] {
+	return c.updateNews.CallClientStream(ctx)
+}
+
+// DeletedNews calls news.v1.NewsService.DeletedNews.
+func (c *newsServiceClient) DeletedNews(ctx context.Context) *connect.BidiStreamForClient[v1.NewsID, v1.DeletedNewsResponse] {
+	return c.deletedNews.CallBidiStream(ctx)
+}
+
+// SubmitNews calls news.v1.NewsService.SubmitNews.
+func (c *newsServiceClient) SubmitNews(ctx context.Context, req *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error) {
+	return c.submitNews.CallUnary(ctx, req)
+}
+
+// ApproveNews calls news.v1.NewsService.ApproveNews.
+func (c *newsServiceClient) ApproveNews(ctx context.Context, req *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error) {
+	return c.approveNews.CallUnary(ctx, req)
+}
+
+// RejectNews calls news.v1.NewsService.RejectNews.
+func (c *newsServiceClient) RejectNews(ctx context.Context, req *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error) {
+	return c.rejectNews.CallUnary(ctx, req)
+}
+
+// PublishNews calls news.v1.NewsService.PublishNews.
+func (c *newsServiceClient) PublishNews(ctx context.Context, req *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error) {
+	return c.publishNews.CallUnary(ctx, req)
+}
+
+// ArchiveNews calls news.v1.NewsService.ArchiveNews.
+func (c *newsServiceClient) ArchiveNews(ctx context.Context, req *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error) {
+	return c.archiveNews.CallUnary(ctx, req)
+}
+
+// ListTrash calls news.v1.NewsService.ListTrash.
+func (c *newsServiceClient) ListTrash(ctx context.Context, req *connect.Request[v1.ListTrashRequest]) (*connect.Response[v1.ListTrashResponse], error) {
+	return c.listTrash.CallUnary(ctx, req)
+}
+
+// RestoreNews calls news.v1.NewsService.RestoreNews.
+func (c *newsServiceClient) RestoreNews(ctx context.Context, req *connect.Request[v1.RestoreNewsRequest]) (*connect.Response[v1.News], error) {
+	return c.restoreNews.CallUnary(ctx, req)
+}
+
+// PurgeNews calls news.v1.NewsService.PurgeNews.
+func (c *newsServiceClient) PurgeNews(ctx context.Context, req *connect.Request[v1.PurgeNewsRequest]) (*connect.Response[v1.PurgeNewsResponse], error) {
+	return c.purgeNews.CallUnary(ctx, req)
+}
+
+// EmptyTrash calls news.v1.NewsService.EmptyTrash.
+func (c *newsServiceClient) EmptyTrash(ctx context.Context, req *connect.Request[v1.EmptyTrashRequest]) (*connect.Response[v1.EmptyTrashResponse], error) {
+	return c.emptyTrash.CallUnary(ctx, req)
+}
+
+// NewsServiceHandler is an implementation of the news.v1.NewsService service.
+type NewsServiceHandler interface {
+	// Creates the news under the id of the request. Retrying with the same id
+	// and content returns the stored news, a different content fails with
+	// ALREADY_EXISTS.
+	Create(context.Context, *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error)
+	// News under embargo are not found unless the caller is privileged
+	Get(context.Context, *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error)
+	// Gets up to 100 news at once from a consistent view, reporting every id
+	// that is invalid, unknown or deleted in its result
+	BatchGetNews(context.Context, *connect.Request[v1.BatchGetNewsRequest]) (*connect.Response[v1.BatchGetNewsResponse], error)
+	// Server side stream of the published news, newline-delimited JSON over
+	// HTTP
+	GetAll(context.Context, *connect.Request[emptypb.Empty], *connect.ServerStream[v1.GetAllResponse]) error
+	// Server side stream of the changes of the news as they are written
+	WatchNews(context.Context, *connect.Request[v1.WatchNewsRequest], *connect.ServerStream[v1.WatchNewsResponse]) error
+	// Changes of the news since a cursor in commit order, for incremental sync.
+	// Fails with OUT_OF_RANGE once news changed after the cursor were purged.
+	ListChanges(context.Context, *connect.Request[v1.ListChangesRequest]) (*connect.Response[v1.ListChangesResponse], error)
+	// Paginated and filterable listing of the news, news under embargo are
+	// left out unless the caller is privileged
+	ListNews(context.Context, *connect.Request[v1.ListNewsRequest]) (*connect.Response[v1.ListNewsResponse], error)
+	// Full-text search over the title, summary and content of the news
+	SearchNews(context.Context, *connect.Request[v1.SearchNewsRequest]) (*connect.Response[v1.SearchNewsResponse], error)
+	// Revisions of the news from the oldest to the latest
+	ListRevisions(context.Context, *connect.Request[v1.ListRevisionsRequest]) (*connect.Response[v1.ListRevisionsResponse], error)
+	GetRevision(context.Context, *connect.Request[v1.GetRevisionRequest]) (*connect.Response[v1.Revision], error)
+	// Field-level changes between two revisions of the news
+	DiffRevisions(context.Context, *connect.Request[v1.DiffRevisionsRequest]) (*connect.Response[v1.DiffRevisionsResponse], error)
+	// Restores the content and the schedule of a previous revision as a new
+	// revision
+	RollbackNews(context.Context, *connect.Request[v1.RollbackNewsRequest]) (*connect.Response[v1.News], error)
+	// Updates the fields of the update mask, the other fields are left as is
+	Update(context.Context, *connect.Request[v1.UpdateNewsRequest]) (*connect.Response[v1.News], error)
+	// Client side stream, reports the outcome of every streamed update
+	UpdateNews(context.Context, *connect.ClientStream[v1.UpdateNewsRequest]) (*connect.Response[v1.UpdateNewsResponse], error)
+	// Bidirectional stream, acknowledges every streamed id with its outcome
+	DeletedNews(context.Context, *connect.BidiStream[v1.NewsID, v1.DeletedNewsResponse]) error
+	// Submits a draft for review
+	SubmitNews(context.Context, *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error)
+	// Publishes a news in review
+	ApproveNews(context.Context, *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error)
+	// Sends a news in review back to draft
+	RejectNews(context.Context, *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error)
+	// Publishes a draft without review, or an archived news again
+	PublishNews(context.Context, *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error)
+	// Withdraws a published news from the readers
+	ArchiveNews(context.Context, *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error)
+	// Soft deleted news in the order of their deletion
+	ListTrash(context.Context, *connect.Request[v1.ListTrashRequest]) (*connect.Response[v1.ListTrashResponse], error)
+	// Clears the deletion of a soft deleted news
+	RestoreNews(context.Context, *connect.Request[v1.RestoreNewsRequest]) (*connect.Response[v1.News], error)
+	// Permanently deletes soft deleted news along with their revisions
+	PurgeNews(context.Context, *connect.Request[v1.PurgeNewsRequest]) (*connect.Response[v1.PurgeNewsResponse], error)
+	// Permanently deletes the news soft deleted before a timestamp
+	EmptyTrash(context.Context, *connect.Request[v1.EmptyTrashRequest]) (*connect.Response[v1.EmptyTrashResponse], error)
+}
+
+// NewNewsServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewNewsServiceHandler(svc NewsServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	newsServiceMethods := v1.File_news_v1_service_proto.Services().ByName("NewsService").Methods()
+	newsServiceCreateHandler := connect.NewUnaryHandler(
+		NewsServiceCreateProcedure,
+		svc.Create,
+		connect.WithSchema(newsServiceMethods.ByName("Create")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceGetHandler := connect.NewUnaryHandler(
+		NewsServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(newsServiceMethods.ByName("Get")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceBatchGetNewsHandler := connect.NewUnaryHandler(
+		NewsServiceBatchGetNewsProcedure,
+		svc.BatchGetNews,
+		connect.WithSchema(newsServiceMethods.ByName("BatchGetNews")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceGetAllHandler := connect.NewServerStreamHandler(
+		NewsServiceGetAllProcedure,
+		svc.GetAll,
+		connect.WithSchema(newsServiceMethods.ByName("GetAll")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceWatchNewsHandler := connect.NewServerStreamHandler(
+		NewsServiceWatchNewsProcedure,
+		svc.WatchNews,
+		connect.WithSchema(newsServiceMethods.ByName("WatchNews")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceListChangesHandler := connect.NewUnaryHandler(
+		NewsServiceListChangesProcedure,
+		svc.ListChanges,
+		connect.WithSchema(newsServiceMethods.ByName("ListChanges")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceListNewsHandler := connect.NewUnaryHandler(
+		NewsServiceListNewsProcedure,
+		svc.ListNews,
+		connect.WithSchema(newsServiceMethods.ByName("ListNews")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceSearchNewsHandler := connect.NewUnaryHandler(
+		NewsServiceSearchNewsProcedure,
+		svc.SearchNews,
+		connect.WithSchema(newsServiceMethods.ByName("SearchNews")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceListRevisionsHandler := connect.NewUnaryHandler(
+		NewsServiceListRevisionsProcedure,
+		svc.ListRevisions,
+		connect.WithSchema(newsServiceMethods.ByName("ListRevisions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceGetRevisionHandler := connect.NewUnaryHandler(
+		NewsServiceGetRevisionProcedure,
+		svc.GetRevision,
+		connect.WithSchema(newsServiceMethods.ByName("GetRevision")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceDiffRevisionsHandler := connect.NewUnaryHandler(
+		NewsServiceDiffRevisionsProcedure,
+		svc.DiffRevisions,
+		connect.WithSchema(newsServiceMethods.ByName("DiffRevisions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceRollbackNewsHandler := connect.NewUnaryHandler(
+		NewsServiceRollbackNewsProcedure,
+		svc.RollbackNews,
+		connect.WithSchema(newsServiceMethods.ByName("RollbackNews")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceUpdateHandler := connect.NewUnaryHandler(
+		NewsServiceUpdateProcedure,
+		svc.Update,
+		connect.WithSchema(newsServiceMethods.ByName("Update")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceUpdateNewsHandler := connect.NewClientStreamHandler(
+		NewsServiceUpdateNewsProcedure,
+		svc.UpdateNews,
+		connect.WithSchema(newsServiceMethods.ByName("UpdateNews")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceDeletedNewsHandler := connect.NewBidiStreamHandler(
+		NewsServiceDeletedNewsProcedure,
+		svc.DeletedNews,
+		connect.WithSchema(newsServiceMethods.ByName("DeletedNews")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceSubmitNewsHandler := connect.NewUnaryHandler(
+		NewsServiceSubmitNewsProcedure,
+		svc.SubmitNews,
+		connect.WithSchema(newsServiceMethods.ByName("SubmitNews")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceApproveNewsHandler := connect.NewUnaryHandler(
+		NewsServiceApproveNewsProcedure,
+		svc.ApproveNews,
+		connect.WithSchema(newsServiceMethods.ByName("ApproveNews")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceRejectNewsHandler := connect.NewUnaryHandler(
+		NewsServiceRejectNewsProcedure,
+		svc.RejectNews,
+		connect.WithSchema(newsServiceMethods.ByName("RejectNews")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServicePublishNewsHandler := connect.NewUnaryHandler(
+		NewsServicePublishNewsProcedure,
+		svc.PublishNews,
+		connect.WithSchema(newsServiceMethods.ByName("PublishNews")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceArchiveNewsHandler := connect.NewUnaryHandler(
+		NewsServiceArchiveNewsProcedure,
+		svc.ArchiveNews,
+		connect.WithSchema(newsServiceMethods.ByName("ArchiveNews")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceListTrashHandler := connect.NewUnaryHandler(
+		NewsServiceListTrashProcedure,
+		svc.ListTrash,
+		connect.WithSchema(newsServiceMethods.ByName("ListTrash")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceRestoreNewsHandler := connect.NewUnaryHandler(
+		NewsServiceRestoreNewsProcedure,
+		svc.RestoreNews,
+		connect.WithSchema(newsServiceMethods.ByName("RestoreNews")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServicePurgeNewsHandler := connect.NewUnaryHandler(
+		NewsServicePurgeNewsProcedure,
+		svc.PurgeNews,
+		connect.WithSchema(newsServiceMethods.ByName("PurgeNews")),
+		connect.WithHandlerOptions(opts...),
+	)
+	newsServiceEmptyTrashHandler := connect.NewUnaryHandler(
+		NewsServiceEmptyTrashProcedure,
+		svc.EmptyTrash,
+		connect.WithSchema(newsServiceMethods.ByName("EmptyTrash")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/news.v1.NewsService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case NewsServiceCreateProcedure:
+			newsServiceCreateHandler.ServeHTTP(w, r)
+		case NewsServiceGetProcedure:
+			newsServiceGetHandler.ServeHTTP(w, r)
+		case NewsServiceBatchGetNewsProcedure:
+			newsServiceBatchGetNewsHandler.ServeHTTP(w, r)
+		case NewsServiceGetAllProcedure:
+			newsServiceGetAllHandler.ServeHTTP(w, r)
+		case NewsServiceWatchNewsProcedure:
+			newsServiceWatchNewsHandler.ServeHTTP(w, r)
+		case NewsServiceListChangesProcedure:
+			newsServiceListChangesHandler.ServeHTTP(w, r)
+		case NewsServiceListNewsProcedure:
+			newsServiceListNewsHandler.ServeHTTP(w, r)
+		case NewsServiceSearchNewsProcedure:
+			newsServiceSearchNewsHandler.ServeHTTP(w, r)
+		case NewsServiceListRevisionsProcedure:
+			newsServiceListRevisionsHandler.ServeHTTP(w, r)
+		case NewsServiceGetRevisionProcedure:
+			newsServiceGetRevisionHandler.ServeHTTP(w, r)
+		case NewsServiceDiffRevisionsProcedure:
+			newsServiceDiffRevisionsHandler.ServeHTTP(w, r)
+		case NewsServiceRollbackNewsProcedure:
+			newsServiceRollbackNewsHandler.ServeHTTP(w, r)
+		case NewsServiceUpdateProcedure:
+			newsServiceUpdateHandler.ServeHTTP(w, r)
+		case NewsServiceUpdateNewsProcedure:
+			newsServiceUpdateNewsHandler.ServeHTTP(w, r)
+		case NewsServiceDeletedNewsProcedure:
+			newsServiceDeletedNewsHandler.ServeHTTP(w, r)
+		case NewsServiceSubmitNewsProcedure:
+			newsServiceSubmitNewsHandler.ServeHTTP(w, r)
+		case NewsServiceApproveNewsProcedure:
+			newsServiceApproveNewsHandler.ServeHTTP(w, r)
+		case NewsServiceRejectNewsProcedure:
+			newsServiceRejectNewsHandler.ServeHTTP(w, r)
+		case NewsServicePublishNewsProcedure:
+			newsServicePublishNewsHandler.ServeHTTP(w, r)
+		case NewsServiceArchiveNewsProcedure:
+			newsServiceArchiveNewsHandler.ServeHTTP(w, r)
+		case NewsServiceListTrashProcedure:
+			newsServiceListTrashHandler.ServeHTTP(w, r)
+		case NewsServiceRestoreNewsProcedure:
+			newsServiceRestoreNewsHandler.ServeHTTP(w, r)
+		case NewsServicePurgeNewsProcedure:
+			newsServicePurgeNewsHandler.ServeHTTP(w, r)
+		case NewsServiceEmptyTrashProcedure:
+			newsServiceEmptyTrashHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedNewsServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedNewsServiceHandler struct{}
+
+func (UnimplementedNewsServiceHandler) Create(context.Context, *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.Create is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) Get(context.Context, *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.Get is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) BatchGetNews(context.Context, *connect.Request[v1.BatchGetNewsRequest]) (*connect.Response[v1.BatchGetNewsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.BatchGetNews is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) GetAll(context.Context, *connect.Request[emptypb.Empty], *connect.ServerStream[v1.GetAllResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.GetAll is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) WatchNews(context.Context, *connect.Request[v1.WatchNewsRequest], *connect.ServerStream[v1.WatchNewsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.WatchNews is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) ListChanges(context.Context, *connect.Request[v1.ListChangesRequest]) (*connect.Response[v1.ListChangesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.ListChanges is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) ListNews(context.Context, *connect.Request[v1.ListNewsRequest]) (*connect.Response[v1.ListNewsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.ListNews is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) SearchNews(context.Context, *connect.Request[v1.SearchNewsRequest]) (*connect.Response[v1.SearchNewsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.SearchNews is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) ListRevisions(context.Context, *connect.Request[v1.ListRevisionsRequest]) (*connect.Response[v1.ListRevisionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.ListRevisions is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) GetRevision(context.Context, *connect.Request[v1.GetRevisionRequest]) (*connect.Response[v1.Revision], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.GetRevision is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) DiffRevisions(context.Context, *connect.Request[v1.DiffRevisionsRequest]) (*connect.Response[v1.DiffRevisionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.DiffRevisions is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) RollbackNews(context.Context, *connect.Request[v1.RollbackNewsRequest]) (*connect.Response[v1.News], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.RollbackNews is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) Update(context.Context, *connect.Request[v1.UpdateNewsRequest]) (*connect.Response[v1.News], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.Update is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) UpdateNews(context.Context, *connect.ClientStream[v1.UpdateNewsRequest]) (*connect.Response[v1.UpdateNewsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.UpdateNews is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) DeletedNews(context.Context, *connect.BidiStream[v1.NewsID, v1.DeletedNewsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.DeletedNews is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) SubmitNews(context.Context, *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.SubmitNews is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) ApproveNews(context.Context, *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.ApproveNews is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) RejectNews(context.Context, *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.RejectNews is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) PublishNews(context.Context, *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.PublishNews is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) ArchiveNews(context.Context, *connect.Request[v1.TransitionNewsRequest]) (*connect.Response[v1.News], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.ArchiveNews is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) ListTrash(context.Context, *connect.Request[v1.ListTrashRequest]) (*connect.Response[v1.ListTrashResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.ListTrash is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) RestoreNews(context.Context, *connect.Request[v1.RestoreNewsRequest]) (*connect.Response[v1.News], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.RestoreNews is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) PurgeNews(context.Context, *connect.Request[v1.PurgeNewsRequest]) (*connect.Response[v1.PurgeNewsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.PurgeNews is not implemented"))
+}
+
+func (UnimplementedNewsServiceHandler) EmptyTrash(context.Context, *connect.Request[v1.EmptyTrashRequest]) (*connect.Response[v1.EmptyTrashResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("news.v1.NewsService.EmptyTrash is not implemented"))
+}
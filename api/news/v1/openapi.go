@@ -0,0 +1,9 @@
+package newsv1
+
+import _ "embed"
+
+// OpenAPI document of the JSON/HTTP mapping of NewsService, generated from the
+// google.api.http annotations of the service.
+//
+//go:embed openapi.swagger.json
+var OpenAPI []byte
@@ -20,25 +20,90 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	NewsService_Create_FullMethodName      = "/news.v1.NewsService/Create"
-	NewsService_Get_FullMethodName         = "/news.v1.NewsService/Get"
-	NewsService_GetAll_FullMethodName      = "/news.v1.NewsService/GetAll"
-	NewsService_UpdateNews_FullMethodName  = "/news.v1.NewsService/UpdateNews"
-	NewsService_DeletedNews_FullMethodName = "/news.v1.NewsService/DeletedNews"
+	NewsService_Create_FullMethodName        = "/news.v1.NewsService/Create"
+	NewsService_Get_FullMethodName           = "/news.v1.NewsService/Get"
+	NewsService_BatchGetNews_FullMethodName  = "/news.v1.NewsService/BatchGetNews"
+	NewsService_GetAll_FullMethodName        = "/news.v1.NewsService/GetAll"
+	NewsService_WatchNews_FullMethodName     = "/news.v1.NewsService/WatchNews"
+	NewsService_ListChanges_FullMethodName   = "/news.v1.NewsService/ListChanges"
+	NewsService_ListNews_FullMethodName      = "/news.v1.NewsService/ListNews"
+	NewsService_SearchNews_FullMethodName    = "/news.v1.NewsService/SearchNews"
+	NewsService_ListRevisions_FullMethodName = "/news.v1.NewsService/ListRevisions"
+	NewsService_GetRevision_FullMethodName   = "/news.v1.NewsService/GetRevision"
+	NewsService_DiffRevisions_FullMethodName = "/news.v1.NewsService/DiffRevisions"
+	NewsService_RollbackNews_FullMethodName  = "/news.v1.NewsService/RollbackNews"
+	NewsService_Update_FullMethodName        = "/news.v1.NewsService/Update"
+	NewsService_UpdateNews_FullMethodName    = "/news.v1.NewsService/UpdateNews"
+	NewsService_DeletedNews_FullMethodName   = "/news.v1.NewsService/DeletedNews"
+	NewsService_SubmitNews_FullMethodName    = "/news.v1.NewsService/SubmitNews"
+	NewsService_ApproveNews_FullMethodName   = "/news.v1.NewsService/ApproveNews"
+	NewsService_RejectNews_FullMethodName    = "/news.v1.NewsService/RejectNews"
+	NewsService_PublishNews_FullMethodName   = "/news.v1.NewsService/PublishNews"
+	NewsService_ArchiveNews_FullMethodName   = "/news.v1.NewsService/ArchiveNews"
+	NewsService_ListTrash_FullMethodName     = "/news.v1.NewsService/ListTrash"
+	NewsService_RestoreNews_FullMethodName   = "/news.v1.NewsService/RestoreNews"
+	NewsService_PurgeNews_FullMethodName     = "/news.v1.NewsService/PurgeNews"
+	NewsService_EmptyTrash_FullMethodName    = "/news.v1.NewsService/EmptyTrash"
 )
 
 // NewsServiceClient is the client API for NewsService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type NewsServiceClient interface {
+	// Creates the news under the id of the request. Retrying with the same id
+	// and content returns the stored news, a different content fails with
+	// ALREADY_EXISTS.
 	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	// News under embargo are not found unless the caller is privileged
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
-	// Server side stream
+	// Gets up to 100 news at once from a consistent view, reporting every id
+	// that is invalid, unknown or deleted in its result
+	BatchGetNews(ctx context.Context, in *BatchGetNewsRequest, opts ...grpc.CallOption) (*BatchGetNewsResponse, error)
+	// Server side stream of the published news, newline-delimited JSON over
+	// HTTP
 	GetAll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetAllResponse], error)
-	// Client side stream
-	UpdateNews(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateRequest, emptypb.Empty], error)
-	// Bidirectional stream
-	DeletedNews(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[NewsID, emptypb.Empty], error)
+	// Server side stream of the changes of the news as they are written
+	WatchNews(ctx context.Context, in *WatchNewsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchNewsResponse], error)
+	// Changes of the news since a cursor in commit order, for incremental sync.
+	// Fails with OUT_OF_RANGE once news changed after the cursor were purged.
+	ListChanges(ctx context.Context, in *ListChangesRequest, opts ...grpc.CallOption) (*ListChangesResponse, error)
+	// Paginated and filterable listing of the news, news under embargo are
+	// left out unless the caller is privileged
+	ListNews(ctx context.Context, in *ListNewsRequest, opts ...grpc.CallOption) (*ListNewsResponse, error)
+	// Full-text search over the title, summary and content of the news
+	SearchNews(ctx context.Context, in *SearchNewsRequest, opts ...grpc.CallOption) (*SearchNewsResponse, error)
+	// Revisions of the news from the oldest to the latest
+	ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error)
+	GetRevision(ctx context.Context, in *GetRevisionRequest, opts ...grpc.CallOption) (*Revision, error)
+	// Field-level changes between two revisions of the news
+	DiffRevisions(ctx context.Context, in *DiffRevisionsRequest, opts ...grpc.CallOption) (*DiffRevisionsResponse, error)
+	// Restores the content and the schedule of a previous revision as a new
+	// revision
+	RollbackNews(ctx context.Context, in *RollbackNewsRequest, opts ...grpc.CallOption) (*News, error)
+	// Updates the fields of the update mask, the other fields are left as is
+	Update(ctx context.Context, in *UpdateNewsRequest, opts ...grpc.CallOption) (*News, error)
+	// Client side stream, reports the outcome of every streamed update
+	UpdateNews(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UpdateNewsRequest, UpdateNewsResponse], error)
+	// Bidirectional stream, acknowledges every streamed id with its outcome
+	DeletedNews(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[NewsID, DeletedNewsResponse], error)
+	// Submits a draft for review
+	SubmitNews(ctx context.Context, in *TransitionNewsRequest, opts ...grpc.CallOption) (*News, error)
+	// Publishes a news in review
+	ApproveNews(ctx context.Context, in *TransitionNewsRequest, opts ...grpc.CallOption) (*News, error)
+	// Sends a news in review back to draft
+	RejectNews(ctx context.Context, in *TransitionNewsRequest, opts ...grpc.CallOption) (*News, error)
+	// Publishes a draft without review, or an archived news again
+	PublishNews(ctx context.Context, in *TransitionNewsRequest, opts ...grpc.CallOption) (*News, error)
+	// Withdraws a published news from the readers
+	ArchiveNews(ctx context.Context, in *TransitionNewsRequest, opts ...grpc.CallOption) (*News, error)
+	// Soft deleted news in the order of their deletion
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error)
+	// Clears the deletion of a soft deleted news
+	RestoreNews(ctx context.Context, in *RestoreNewsRequest, opts ...grpc.CallOption) (*News, error)
+	// Permanently deletes soft deleted news along with their revisions
+	PurgeNews(ctx context.Context, in *PurgeNewsRequest, opts ...grpc.CallOption) (*PurgeNewsResponse, error)
+	// Permanently deletes the news soft deleted before a timestamp
+	EmptyTrash(ctx context.Context, in *EmptyTrashRequest, opts ...grpc.CallOption) (*EmptyTrashResponse, error)
 }
 
 type newsServiceClient struct {
@@ -69,6 +134,16 @@ func (c *newsServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grp
 	return out, nil
 }
 
+func (c *newsServiceClient) BatchGetNews(ctx context.Context, in *BatchGetNewsRequest, opts ...grpc.CallOption) (*BatchGetNewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetNewsResponse)
+	err := c.cc.Invoke(ctx, NewsService_BatchGetNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *newsServiceClient) GetAll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetAllResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &NewsService_ServiceDesc.Streams[0], NewsService_GetAll_FullMethodName, cOpts...)
@@ -88,44 +163,279 @@ func (c *newsServiceClient) GetAll(ctx context.Context, in *emptypb.Empty, opts
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type NewsService_GetAllClient = grpc.ServerStreamingClient[GetAllResponse]
 
-func (c *newsServiceClient) UpdateNews(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateRequest, emptypb.Empty], error) {
+func (c *newsServiceClient) WatchNews(ctx context.Context, in *WatchNewsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchNewsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NewsService_ServiceDesc.Streams[1], NewsService_WatchNews_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchNewsRequest, WatchNewsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NewsService_WatchNewsClient = grpc.ServerStreamingClient[WatchNewsResponse]
+
+func (c *newsServiceClient) ListChanges(ctx context.Context, in *ListChangesRequest, opts ...grpc.CallOption) (*ListChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChangesResponse)
+	err := c.cc.Invoke(ctx, NewsService_ListChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) ListNews(ctx context.Context, in *ListNewsRequest, opts ...grpc.CallOption) (*ListNewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNewsResponse)
+	err := c.cc.Invoke(ctx, NewsService_ListNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) SearchNews(ctx context.Context, in *SearchNewsRequest, opts ...grpc.CallOption) (*SearchNewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchNewsResponse)
+	err := c.cc.Invoke(ctx, NewsService_SearchNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRevisionsResponse)
+	err := c.cc.Invoke(ctx, NewsService_ListRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) GetRevision(ctx context.Context, in *GetRevisionRequest, opts ...grpc.CallOption) (*Revision, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Revision)
+	err := c.cc.Invoke(ctx, NewsService_GetRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) DiffRevisions(ctx context.Context, in *DiffRevisionsRequest, opts ...grpc.CallOption) (*DiffRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffRevisionsResponse)
+	err := c.cc.Invoke(ctx, NewsService_DiffRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) RollbackNews(ctx context.Context, in *RollbackNewsRequest, opts ...grpc.CallOption) (*News, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(News)
+	err := c.cc.Invoke(ctx, NewsService_RollbackNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) Update(ctx context.Context, in *UpdateNewsRequest, opts ...grpc.CallOption) (*News, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(News)
+	err := c.cc.Invoke(ctx, NewsService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) UpdateNews(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UpdateNewsRequest, UpdateNewsResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &NewsService_ServiceDesc.Streams[1], NewsService_UpdateNews_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &NewsService_ServiceDesc.Streams[2], NewsService_UpdateNews_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[CreateRequest, emptypb.Empty]{ClientStream: stream}
+	x := &grpc.GenericClientStream[UpdateNewsRequest, UpdateNewsResponse]{ClientStream: stream}
 	return x, nil
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type NewsService_UpdateNewsClient = grpc.ClientStreamingClient[CreateRequest, emptypb.Empty]
+type NewsService_UpdateNewsClient = grpc.ClientStreamingClient[UpdateNewsRequest, UpdateNewsResponse]
 
-func (c *newsServiceClient) DeletedNews(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[NewsID, emptypb.Empty], error) {
+func (c *newsServiceClient) DeletedNews(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[NewsID, DeletedNewsResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &NewsService_ServiceDesc.Streams[2], NewsService_DeletedNews_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &NewsService_ServiceDesc.Streams[3], NewsService_DeletedNews_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[NewsID, emptypb.Empty]{ClientStream: stream}
+	x := &grpc.GenericClientStream[NewsID, DeletedNewsResponse]{ClientStream: stream}
 	return x, nil
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type NewsService_DeletedNewsClient = grpc.BidiStreamingClient[NewsID, emptypb.Empty]
+type NewsService_DeletedNewsClient = grpc.BidiStreamingClient[NewsID, DeletedNewsResponse]
+
+func (c *newsServiceClient) SubmitNews(ctx context.Context, in *TransitionNewsRequest, opts ...grpc.CallOption) (*News, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(News)
+	err := c.cc.Invoke(ctx, NewsService_SubmitNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) ApproveNews(ctx context.Context, in *TransitionNewsRequest, opts ...grpc.CallOption) (*News, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(News)
+	err := c.cc.Invoke(ctx, NewsService_ApproveNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) RejectNews(ctx context.Context, in *TransitionNewsRequest, opts ...grpc.CallOption) (*News, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(News)
+	err := c.cc.Invoke(ctx, NewsService_RejectNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) PublishNews(ctx context.Context, in *TransitionNewsRequest, opts ...grpc.CallOption) (*News, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(News)
+	err := c.cc.Invoke(ctx, NewsService_PublishNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) ArchiveNews(ctx context.Context, in *TransitionNewsRequest, opts ...grpc.CallOption) (*News, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(News)
+	err := c.cc.Invoke(ctx, NewsService_ArchiveNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, NewsService_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) RestoreNews(ctx context.Context, in *RestoreNewsRequest, opts ...grpc.CallOption) (*News, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(News)
+	err := c.cc.Invoke(ctx, NewsService_RestoreNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) PurgeNews(ctx context.Context, in *PurgeNewsRequest, opts ...grpc.CallOption) (*PurgeNewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeNewsResponse)
+	err := c.cc.Invoke(ctx, NewsService_PurgeNews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) EmptyTrash(ctx context.Context, in *EmptyTrashRequest, opts ...grpc.CallOption) (*EmptyTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyTrashResponse)
+	err := c.cc.Invoke(ctx, NewsService_EmptyTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
 // NewsServiceServer is the server API for NewsService service.
 // All implementations must embed UnimplementedNewsServiceServer
 // for forward compatibility.
 type NewsServiceServer interface {
+	// Creates the news under the id of the request. Retrying with the same id
+	// and content returns the stored news, a different content fails with
+	// ALREADY_EXISTS.
 	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	// News under embargo are not found unless the caller is privileged
 	Get(context.Context, *GetRequest) (*GetResponse, error)
-	// Server side stream
+	// Gets up to 100 news at once from a consistent view, reporting every id
+	// that is invalid, unknown or deleted in its result
+	BatchGetNews(context.Context, *BatchGetNewsRequest) (*BatchGetNewsResponse, error)
+	// Server side stream of the published news, newline-delimited JSON over
+	// HTTP
 	GetAll(*emptypb.Empty, grpc.ServerStreamingServer[GetAllResponse]) error
-	// Client side stream
-	UpdateNews(grpc.ClientStreamingServer[CreateRequest, emptypb.Empty]) error
-	// Bidirectional stream
-	DeletedNews(grpc.BidiStreamingServer[NewsID, emptypb.Empty]) error
+	// Server side stream of the changes of the news as they are written
+	WatchNews(*WatchNewsRequest, grpc.ServerStreamingServer[WatchNewsResponse]) error
+	// Changes of the news since a cursor in commit order, for incremental sync.
+	// Fails with OUT_OF_RANGE once news changed after the cursor were purged.
+	ListChanges(context.Context, *ListChangesRequest) (*ListChangesResponse, error)
+	// Paginated and filterable listing of the news, news under embargo are
+	// left out unless the caller is privileged
+	ListNews(context.Context, *ListNewsRequest) (*ListNewsResponse, error)
+	// Full-text search over the title, summary and content of the news
+	SearchNews(context.Context, *SearchNewsRequest) (*SearchNewsResponse, error)
+	// Revisions of the news from the oldest to the latest
+	ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error)
+	GetRevision(context.Context, *GetRevisionRequest) (*Revision, error)
+	// Field-level changes between two revisions of the news
+	DiffRevisions(context.Context, *DiffRevisionsRequest) (*DiffRevisionsResponse, error)
+	// Restores the content and the schedule of a previous revision as a new
+	// revision
+	RollbackNews(context.Context, *RollbackNewsRequest) (*News, error)
+	// Updates the fields of the update mask, the other fields are left as is
+	Update(context.Context, *UpdateNewsRequest) (*News, error)
+	// Client side stream, reports the outcome of every streamed update
+	UpdateNews(grpc.ClientStreamingServer[UpdateNewsRequest, UpdateNewsResponse]) error
+	// Bidirectional stream, acknowledges every streamed id with its outcome
+	DeletedNews(grpc.BidiStreamingServer[NewsID, DeletedNewsResponse]) error
+	// Submits a draft for review
+	SubmitNews(context.Context, *TransitionNewsRequest) (*News, error)
+	// Publishes a news in review
+	ApproveNews(context.Context, *TransitionNewsRequest) (*News, error)
+	// Sends a news in review back to draft
+	RejectNews(context.Context, *TransitionNewsRequest) (*News, error)
+	// Publishes a draft without review, or an archived news again
+	PublishNews(context.Context, *TransitionNewsRequest) (*News, error)
+	// Withdraws a published news from the readers
+	ArchiveNews(context.Context, *TransitionNewsRequest) (*News, error)
+	// Soft deleted news in the order of their deletion
+	ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error)
+	// Clears the deletion of a soft deleted news
+	RestoreNews(context.Context, *RestoreNewsRequest) (*News, error)
+	// Permanently deletes soft deleted news along with their revisions
+	PurgeNews(context.Context, *PurgeNewsRequest) (*PurgeNewsResponse, error)
+	// Permanently deletes the news soft deleted before a timestamp
+	EmptyTrash(context.Context, *EmptyTrashRequest) (*EmptyTrashResponse, error)
 	mustEmbedUnimplementedNewsServiceServer()
 }
 
@@ -142,15 +452,72 @@ func (UnimplementedNewsServiceServer) Create(context.Context, *CreateRequest) (*
 func (UnimplementedNewsServiceServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (UnimplementedNewsServiceServer) BatchGetNews(context.Context, *BatchGetNewsRequest) (*BatchGetNewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetNews not implemented")
+}
 func (UnimplementedNewsServiceServer) GetAll(*emptypb.Empty, grpc.ServerStreamingServer[GetAllResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method GetAll not implemented")
 }
-func (UnimplementedNewsServiceServer) UpdateNews(grpc.ClientStreamingServer[CreateRequest, emptypb.Empty]) error {
+func (UnimplementedNewsServiceServer) WatchNews(*WatchNewsRequest, grpc.ServerStreamingServer[WatchNewsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNews not implemented")
+}
+func (UnimplementedNewsServiceServer) ListChanges(context.Context, *ListChangesRequest) (*ListChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChanges not implemented")
+}
+func (UnimplementedNewsServiceServer) ListNews(context.Context, *ListNewsRequest) (*ListNewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNews not implemented")
+}
+func (UnimplementedNewsServiceServer) SearchNews(context.Context, *SearchNewsRequest) (*SearchNewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchNews not implemented")
+}
+func (UnimplementedNewsServiceServer) ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRevisions not implemented")
+}
+func (UnimplementedNewsServiceServer) GetRevision(context.Context, *GetRevisionRequest) (*Revision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevision not implemented")
+}
+func (UnimplementedNewsServiceServer) DiffRevisions(context.Context, *DiffRevisionsRequest) (*DiffRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffRevisions not implemented")
+}
+func (UnimplementedNewsServiceServer) RollbackNews(context.Context, *RollbackNewsRequest) (*News, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackNews not implemented")
+}
+func (UnimplementedNewsServiceServer) Update(context.Context, *UpdateNewsRequest) (*News, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedNewsServiceServer) UpdateNews(grpc.ClientStreamingServer[UpdateNewsRequest, UpdateNewsResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method UpdateNews not implemented")
 }
-func (UnimplementedNewsServiceServer) DeletedNews(grpc.BidiStreamingServer[NewsID, emptypb.Empty]) error {
+func (UnimplementedNewsServiceServer) DeletedNews(grpc.BidiStreamingServer[NewsID, DeletedNewsResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method DeletedNews not implemented")
 }
+func (UnimplementedNewsServiceServer) SubmitNews(context.Context, *TransitionNewsRequest) (*News, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitNews not implemented")
+}
+func (UnimplementedNewsServiceServer) ApproveNews(context.Context, *TransitionNewsRequest) (*News, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveNews not implemented")
+}
+func (UnimplementedNewsServiceServer) RejectNews(context.Context, *TransitionNewsRequest) (*News, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectNews not implemented")
+}
+func (UnimplementedNewsServiceServer) PublishNews(context.Context, *TransitionNewsRequest) (*News, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishNews not implemented")
+}
+func (UnimplementedNewsServiceServer) ArchiveNews(context.Context, *TransitionNewsRequest) (*News, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveNews not implemented")
+}
+func (UnimplementedNewsServiceServer) ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedNewsServiceServer) RestoreNews(context.Context, *RestoreNewsRequest) (*News, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreNews not implemented")
+}
+func (UnimplementedNewsServiceServer) PurgeNews(context.Context, *PurgeNewsRequest) (*PurgeNewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeNews not implemented")
+}
+func (UnimplementedNewsServiceServer) EmptyTrash(context.Context, *EmptyTrashRequest) (*EmptyTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EmptyTrash not implemented")
+}
 func (UnimplementedNewsServiceServer) mustEmbedUnimplementedNewsServiceServer() {}
 func (UnimplementedNewsServiceServer) testEmbeddedByValue()                     {}
 
@@ -208,6 +575,24 @@ func _NewsService_Get_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NewsService_BatchGetNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).BatchGetNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_BatchGetNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).BatchGetNews(ctx, req.(*BatchGetNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NewsService_GetAll_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(emptypb.Empty)
 	if err := stream.RecvMsg(m); err != nil {
@@ -219,19 +604,336 @@ func _NewsService_GetAll_Handler(srv interface{}, stream grpc.ServerStream) erro
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type NewsService_GetAllServer = grpc.ServerStreamingServer[GetAllResponse]
 
+func _NewsService_WatchNews_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchNewsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NewsServiceServer).WatchNews(m, &grpc.GenericServerStream[WatchNewsRequest, WatchNewsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NewsService_WatchNewsServer = grpc.ServerStreamingServer[WatchNewsResponse]
+
+func _NewsService_ListChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).ListChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_ListChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).ListChanges(ctx, req.(*ListChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_ListNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).ListNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_ListNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).ListNews(ctx, req.(*ListNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_SearchNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).SearchNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_SearchNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).SearchNews(ctx, req.(*SearchNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_ListRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).ListRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_ListRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).ListRevisions(ctx, req.(*ListRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_GetRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).GetRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_GetRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).GetRevision(ctx, req.(*GetRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_DiffRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).DiffRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_DiffRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).DiffRevisions(ctx, req.(*DiffRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_RollbackNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).RollbackNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_RollbackNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).RollbackNews(ctx, req.(*RollbackNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).Update(ctx, req.(*UpdateNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NewsService_UpdateNews_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(NewsServiceServer).UpdateNews(&grpc.GenericServerStream[CreateRequest, emptypb.Empty]{ServerStream: stream})
+	return srv.(NewsServiceServer).UpdateNews(&grpc.GenericServerStream[UpdateNewsRequest, UpdateNewsResponse]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type NewsService_UpdateNewsServer = grpc.ClientStreamingServer[CreateRequest, emptypb.Empty]
+type NewsService_UpdateNewsServer = grpc.ClientStreamingServer[UpdateNewsRequest, UpdateNewsResponse]
 
 func _NewsService_DeletedNews_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(NewsServiceServer).DeletedNews(&grpc.GenericServerStream[NewsID, emptypb.Empty]{ServerStream: stream})
+	return srv.(NewsServiceServer).DeletedNews(&grpc.GenericServerStream[NewsID, DeletedNewsResponse]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type NewsService_DeletedNewsServer = grpc.BidiStreamingServer[NewsID, emptypb.Empty]
+type NewsService_DeletedNewsServer = grpc.BidiStreamingServer[NewsID, DeletedNewsResponse]
+
+func _NewsService_SubmitNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).SubmitNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_SubmitNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).SubmitNews(ctx, req.(*TransitionNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_ApproveNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).ApproveNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_ApproveNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).ApproveNews(ctx, req.(*TransitionNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_RejectNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).RejectNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_RejectNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).RejectNews(ctx, req.(*TransitionNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_PublishNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).PublishNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_PublishNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).PublishNews(ctx, req.(*TransitionNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_ArchiveNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).ArchiveNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_ArchiveNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).ArchiveNews(ctx, req.(*TransitionNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).ListTrash(ctx, req.(*ListTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_RestoreNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).RestoreNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_RestoreNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).RestoreNews(ctx, req.(*RestoreNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_PurgeNews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeNewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).PurgeNews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_PurgeNews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).PurgeNews(ctx, req.(*PurgeNewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NewsService_EmptyTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NewsServiceServer).EmptyTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NewsService_EmptyTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NewsServiceServer).EmptyTrash(ctx, req.(*EmptyTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
 // NewsService_ServiceDesc is the grpc.ServiceDesc for NewsService service.
 // It's only intended for direct use with grpc.RegisterService,
@@ -248,6 +950,78 @@ var NewsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _NewsService_Get_Handler,
 		},
+		{
+			MethodName: "BatchGetNews",
+			Handler:    _NewsService_BatchGetNews_Handler,
+		},
+		{
+			MethodName: "ListChanges",
+			Handler:    _NewsService_ListChanges_Handler,
+		},
+		{
+			MethodName: "ListNews",
+			Handler:    _NewsService_ListNews_Handler,
+		},
+		{
+			MethodName: "SearchNews",
+			Handler:    _NewsService_SearchNews_Handler,
+		},
+		{
+			MethodName: "ListRevisions",
+			Handler:    _NewsService_ListRevisions_Handler,
+		},
+		{
+			MethodName: "GetRevision",
+			Handler:    _NewsService_GetRevision_Handler,
+		},
+		{
+			MethodName: "DiffRevisions",
+			Handler:    _NewsService_DiffRevisions_Handler,
+		},
+		{
+			MethodName: "RollbackNews",
+			Handler:    _NewsService_RollbackNews_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _NewsService_Update_Handler,
+		},
+		{
+			MethodName: "SubmitNews",
+			Handler:    _NewsService_SubmitNews_Handler,
+		},
+		{
+			MethodName: "ApproveNews",
+			Handler:    _NewsService_ApproveNews_Handler,
+		},
+		{
+			MethodName: "RejectNews",
+			Handler:    _NewsService_RejectNews_Handler,
+		},
+		{
+			MethodName: "PublishNews",
+			Handler:    _NewsService_PublishNews_Handler,
+		},
+		{
+			MethodName: "ArchiveNews",
+			Handler:    _NewsService_ArchiveNews_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _NewsService_ListTrash_Handler,
+		},
+		{
+			MethodName: "RestoreNews",
+			Handler:    _NewsService_RestoreNews_Handler,
+		},
+		{
+			MethodName: "PurgeNews",
+			Handler:    _NewsService_PurgeNews_Handler,
+		},
+		{
+			MethodName: "EmptyTrash",
+			Handler:    _NewsService_EmptyTrash_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -255,6 +1029,11 @@ var NewsService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _NewsService_GetAll_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchNews",
+			Handler:       _NewsService_WatchNews_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "UpdateNews",
 			Handler:       _NewsService_UpdateNews_Handler,
@@ -3,15 +3,24 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
+	"time"
 
 	"buf.build/go/protovalidate"
 	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/codeandlearn1991/news-grpc/internal/certs"
 )
 
 const serviceConfig = `{
@@ -34,17 +43,36 @@ const serviceConfig = `{
 }`
 
 func main() { //nolint:gocyclo // Refactor to reduce complexity
-	conn, err := grpc.NewClient("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()),
+	addr := flag.String("addr", "localhost:50051", "address of the gRPC server")
+	useTLS := flag.Bool("tls", false, "connect over TLS")
+	files := certs.Files{}
+	flag.StringVar(&files.CAFile, "ca-file", "", "PEM bundle verifying the server, the system roots without it")
+	flag.StringVar(&files.CertFile, "cert-file", "", "PEM client certificate presented to the server (mTLS)")
+	flag.StringVar(&files.KeyFile, "key-file", "", "PEM key of the client certificate")
+	serverName := flag.String("server-name", "", "name verified in the server certificate, the host of the address by default")
+	token := flag.String("token", "", "JWT bearer token of the calls")
+	flag.Parse()
+
+	creds, err := transportCredentials(*useTLS, files, *serverName)
+	if err != nil {
+		log.Fatalf("transport credentials: %v", err)
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(creds),
+		grpc.WithPerRPCCredentials(bearerToken{token: *token, requireTLS: *useTLS}),
 		grpc.WithChainUnaryInterceptor(
 			func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-				log.Println("unary interceptor called")
-				return invoker(ctx, method, req, reply, cc, opts...)
+				start := time.Now()
+				invokeErr := invoker(ctx, method, req, reply, cc, opts...)
+				slog.InfoContext(ctx, "rpc", "method", method, "duration", time.Since(start), "code", status.Code(invokeErr).String())
+				return invokeErr
 			},
 		),
 		grpc.WithChainStreamInterceptor(
 			func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) { //nolint:lll // Refactor to create a separate interceptor.
-				log.Println("stream interceptor called")
-				return streamer(ctx, desc, cc, method, opts...)
+				stream, streamErr := streamer(ctx, desc, cc, method, opts...)
+				slog.InfoContext(ctx, "stream opened", "method", method, "code", status.Code(streamErr).String())
+				return stream, streamErr
 			},
 		),
 		grpc.WithDefaultServiceConfig(serviceConfig),
@@ -64,7 +92,7 @@ func main() { //nolint:gocyclo // Refactor to reduce complexity
 
 	for i := range 5 {
 		msg := &newsv1.CreateRequest{
-			Id:      "test something",
+			Id:      uuid.NewString(),
 			Author:  fmt.Sprintf("Test Author %d", i),
 			Title:   fmt.Sprintf("Test title %d", i),
 			Content: fmt.Sprintf("Test content %d", i),
@@ -79,6 +107,11 @@ func main() { //nolint:gocyclo // Refactor to reduce complexity
 		if err != nil {
 			log.Fatalf("create news: %v", err)
 		}
+		// News are created as drafts, only published news are listed.
+		_, err = client.PublishNews(ctx, &newsv1.TransitionNewsRequest{Id: msg.Id})
+		if err != nil {
+			log.Fatalf("publish news: %v", err)
+		}
 	}
 
 	getAllStream, err := client.GetAll(ctx, &emptypb.Empty{})
@@ -101,30 +134,30 @@ func main() { //nolint:gocyclo // Refactor to reduce complexity
 		allNews = append(allNews, getAllNews)
 	}
 
-	var clientStream grpc.ClientStreamingClient[newsv1.CreateRequest, emptypb.Empty]
+	var clientStream grpc.ClientStreamingClient[newsv1.UpdateNewsRequest, newsv1.UpdateNewsResponse]
 	for i, n := range allNews {
 		clientStream, err = client.UpdateNews(ctx)
 		if err != nil {
 			log.Fatalf("update news stream: %v", err)
 		}
 
-		err = clientStream.Send(&newsv1.CreateRequest{
-			Id:      n.Id,
-			Author:  n.Author + fmt.Sprintf(" updated %d", i),
-			Title:   n.Title,
-			Tags:    n.Tags,
-			Summary: n.Summary,
-			Content: n.Content,
-			Source:  n.Source,
+		err = clientStream.Send(&newsv1.UpdateNewsRequest{
+			News: &newsv1.News{
+				Id:     n.Id,
+				Author: n.Author + fmt.Sprintf(" updated %d", i),
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"author"}},
 		})
 		if err != nil {
 			log.Fatalf("update news send: %v", err)
 		}
 	}
 
-	if _, closeErr := clientStream.CloseAndRecv(); closeErr != nil {
+	updateRes, closeErr := clientStream.CloseAndRecv()
+	if closeErr != nil {
 		log.Fatalf("client stream close: %v", closeErr)
 	}
+	log.Printf("update results: %v", updateRes.Results)
 
 	getAllStream, err = client.GetAll(ctx, &emptypb.Empty{})
 	if err != nil {
@@ -170,7 +203,7 @@ func main() { //nolint:gocyclo // Refactor to reduce complexity
 	}()
 
 	for {
-		_, recvErr := deleteStream.Recv()
+		deleteRes, recvErr := deleteStream.Recv()
 		if errors.Is(recvErr, io.EOF) {
 			log.Printf("delete stream ended: %v", recvErr)
 			break
@@ -178,11 +211,23 @@ func main() { //nolint:gocyclo // Refactor to reduce complexity
 		if recvErr != nil {
 			log.Fatalf("delete stream: %v", recvErr)
 		}
-		log.Println("news deleted")
+		log.Printf("news %s: %v", deleteRes.Id, deleteRes.Outcome)
 	}
 
 	<-waitc
 
+	ids := make([]string, 0, len(allNews))
+	for _, news := range allNews {
+		ids = append(ids, news.Id)
+	}
+	batchRes, err := client.BatchGetNews(ctx, &newsv1.BatchGetNewsRequest{Ids: ids})
+	if err != nil {
+		log.Fatalf("batch get news: %v", err)
+	}
+	for _, result := range batchRes.Results {
+		log.Printf("news %s: %v", result.Id, result.Outcome)
+	}
+
 	getAllStream, err = client.GetAll(ctx, &emptypb.Empty{})
 	if err != nil {
 		log.Fatalf("get all news: %v", err)
@@ -204,3 +249,35 @@ func main() { //nolint:gocyclo // Refactor to reduce complexity
 	}
 	log.Println(allNews)
 }
+
+// transportCredentials returns the TLS credentials of the files, plaintext
+// ones without TLS.
+//
+//nolint:ireturn // TLS is picked by the flags.
+func transportCredentials(useTLS bool, files certs.Files, serverName string) (credentials.TransportCredentials, error) {
+	if !useTLS {
+		return insecure.NewCredentials(), nil
+	}
+	cfg, err := certs.ClientConfig(files, serverName)
+	if err != nil {
+		return nil, fmt.Errorf("client TLS configuration: %w", err)
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// bearerToken attaches the token, when set, to the metadata of the calls.
+type bearerToken struct {
+	token      string
+	requireTLS bool
+}
+
+func (t bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	if t.token == "" {
+		return map[string]string{}, nil
+	}
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+func (t bearerToken) RequireTransportSecurity() bool {
+	return t.requireTLS
+}
@@ -2,23 +2,51 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/api/news/v1/newsv1connect"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/test/bufconn"
 
+	"github.com/codeandlearn1991/news-grpc/internal/auth"
+	"github.com/codeandlearn1991/news-grpc/internal/certs"
+	"github.com/codeandlearn1991/news-grpc/internal/config"
+	inconnect "github.com/codeandlearn1991/news-grpc/internal/connect"
+	"github.com/codeandlearn1991/news-grpc/internal/diskstore"
+	"github.com/codeandlearn1991/news-grpc/internal/gateway"
 	ingrpc "github.com/codeandlearn1991/news-grpc/internal/grpc"
+	"github.com/codeandlearn1991/news-grpc/internal/logging"
 	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/codeandlearn1991/news-grpc/internal/metrics"
+	"github.com/codeandlearn1991/news-grpc/internal/retention"
+	"github.com/codeandlearn1991/news-grpc/internal/schedule"
+	"github.com/codeandlearn1991/news-grpc/internal/search"
+	"github.com/codeandlearn1991/news-grpc/internal/validation"
+	"github.com/codeandlearn1991/news-grpc/internal/watch"
 
 	"buf.build/go/protovalidate"
-	protovalidate_interceptor "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/protovalidate"
+	connectrpc "connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/cors"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -36,53 +64,490 @@ func main() {
 	// 3. Server Side Stream Interceptor -> Server Streaming Calls only
 	// 4. Client Side Stream Interceptor -> Client Streaming Calls only
 
-	validator, err := protovalidate.New()
+	cfg, err := config.Load(os.Args[0], os.Args[1:], os.Environ())
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("configuration: %v", err)
+	}
+	// The log package writes through the logger as well.
+	logger := newLogger(cfg.Log)
+	slog.SetDefault(logger)
+
+	reg := newRegistry()
+	// The interceptors are shared by the gRPC and the Connect servers.
+	unaryInterceptors, streamInterceptors, err := newInterceptors(cfg, logger, reg)
+	if err != nil {
+		log.Fatalf("interceptors initialization: %v", err)
+	}
+	reloader, err := newReloader(cfg.TLS)
+	if err != nil {
+		log.Fatalf("certificates initialization: %v", err)
+	}
+	grpcOpts := serverOptions(cfg.GRPC, unaryInterceptors, streamInterceptors)
+	srv := grpc.NewServer(append(credentialsOptions(reloader), grpcOpts...)...)
+	index := search.NewIndex()
+	hub := watch.NewHub()
+	scheduler := schedule.NewScheduler()
+	store, closeStore, err := newStore(cfg.Store, memstore.WithIndexer(index), memstore.WithIndexer(hub), memstore.WithIndexer(scheduler))
 	if err != nil {
-		log.Fatalf("validator initialization: %v", err)
+		log.Fatalf("store initialization: %v", err)
 	}
 
-	srv := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(protovalidate_interceptor.UnaryServerInterceptor(validator)),
-		grpc.ChainStreamInterceptor(protovalidate_interceptor.StreamServerInterceptor(validator)),
+	newsSrv := ingrpc.NewServer(store,
+		ingrpc.WithSearcher(index),
+		ingrpc.WithWatcher(hub),
+		ingrpc.WithPrivileged(isEditor),
+		ingrpc.WithEditor(subject),
 	)
-	newsv1.RegisterNewsServiceServer(srv, ingrpc.NewServer(memstore.New()))
 	healthSrv := health.NewServer()
-	healthv1.RegisterHealthServer(srv, healthSrv)
+	register := func(s grpc.ServiceRegistrar) {
+		newsv1.RegisterNewsServiceServer(s, newsSrv)
+		healthv1.RegisterHealthServer(s, healthSrv)
+	}
+	register(srv)
 
 	grp, grpCtx := errgroup.WithContext(context.Background())
+	// Background jobs are stopped along with the server.
+	jobsCtx, stopJobs := context.WithCancel(grpCtx)
+
+	startJobs(jobsCtx, grp, cfg, store, scheduler, reloader)
+
+	grp.Go(func() error {
+		return serveGRPC(srv, cfg.GRPC.Addr)
+	})
 
-	grp.Go(func() (err error) {
+	metricsSrv, err := startMetrics(grp, cfg.Metrics.Addr, reg, store)
+	if err != nil {
+		log.Fatalf("metrics initialization: %v", err)
+	}
+
+	var (
+		httpSrv  *http.Server
+		localSrv *grpc.Server
+	)
+	if cfg.Gateway.Addr != "" {
+		// The gateway calls the services in process through a server of its
+		// own, which needs no transport security.
+		localSrv = grpc.NewServer(grpcOpts...)
+		register(localSrv)
+		httpSrv, err = startGateway(grpCtx, grp, cfg, localSrv, serverTLSConfig(reloader))
+		if err != nil {
+			log.Fatalf("gateway initialization: %v", err)
+		}
+	}
+
+	var connectSrv *http.Server
+	if cfg.Connect.Addr != "" {
+		path, handler := newsv1connect.NewNewsServiceHandler(
+			inconnect.NewHandler(newsSrv,
+				inconnect.WithUnaryInterceptors(unaryInterceptors...),
+				inconnect.WithStreamInterceptors(streamInterceptors...),
+			),
+			connectrpc.WithReadMaxBytes(cfg.GRPC.MaxRecvMsgSize),
+			connectrpc.WithSendMaxBytes(cfg.GRPC.MaxSendMsgSize),
+		)
+		connectSrv = newConnectServer(cfg.Connect.Addr, path, handler, cfg.Connect.CORSOrigins, serverTLSConfig(reloader))
+		grp.Go(func() error {
+			return serveHTTP(connectSrv, "connect server")
+		})
+	}
+
+	grp.Go(func() error {
 		defer func() {
 			if r := recover(); r != nil {
 				err = fmt.Errorf("panic: %w", err)
 			}
 		}()
+		interceptSignals(grpCtx)
+		healthSrv.Shutdown()
+		stopJobs()
+		// Watches never end on their own, they would hold the graceful stop.
+		hub.Close()
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(grpCtx), cfg.Shutdown.Timeout)
+		defer cancel()
+		gatewayErr := shutdownGateway(shutdownCtx, httpSrv, localSrv)
+		shutdownHTTP(shutdownCtx, connectSrv, "connect server")
+		shutdownHTTP(shutdownCtx, metricsSrv, "metrics server")
+		return errors.Join(gatewayErr, shutdown(shutdownCtx, srv))
+	})
+
+	waitErr := grp.Wait()
+	if closeErr := closeStore(); closeErr != nil {
+		log.Printf("store close: %v", closeErr)
+	}
+	if waitErr != nil {
+		log.Fatal("server shutdown", waitErr)
+	}
+}
+
+// startJobs runs the background jobs in the group until the context is done.
+func startJobs(
+	ctx context.Context, grp *errgroup.Group, cfg *config.Config,
+	store newsStore, scheduler *schedule.Scheduler, reloader *certs.Reloader,
+) {
+	if cfg.Trash.Retention > 0 {
+		grp.Go(func() error {
+			retention.Run(ctx, store, cfg.Trash.Retention, cfg.Trash.Interval)
+			return nil
+		})
+	}
+	grp.Go(func() error {
+		scheduler.Run(ctx, store)
+		return nil
+	})
+	if reloader != nil {
+		grp.Go(func() error {
+			reloader.Run(ctx, cfg.TLS.ReloadInterval)
+			return nil
+		})
+	}
+}
 
-		lis, err := net.Listen("tcp", ":50051") //nolint:gosec // Okay for the project
+// newInterceptors returns the enabled unary and stream interceptors, from the
+// outermost to the innermost.
+func newInterceptors(
+	cfg *config.Config, logger *slog.Logger, reg prometheus.Registerer,
+) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor, error) {
+	var (
+		unary  []grpc.UnaryServerInterceptor
+		stream []grpc.StreamServerInterceptor
+	)
+	if cfg.Interceptors.Metrics {
+		m, err := metrics.New(reg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("metrics initialization: %w", err)
+		}
+		unary = append(unary, m.UnaryServerInterceptor())
+		stream = append(stream, m.StreamServerInterceptor())
+	}
+	// The calls are logged whatever the interceptors after it make of them.
+	if cfg.Interceptors.Logging {
+		var opts []logging.Option
+		if cfg.Log.Payloads {
+			opts = append(opts, logging.WithPayloads(cfg.Log.Redact...))
+		}
+		unary = append(unary, logging.UnaryServerInterceptor(logger, opts...))
+		stream = append(stream, logging.StreamServerInterceptor(logger, opts...))
+	}
+	// The callers are authorized before their requests are looked at.
+	if cfg.Interceptors.Auth {
+		verifier, err := auth.NewVerifier(cfg.Auth.JWKSFile, cfg.Auth.Issuer, cfg.Auth.Audience)
 		if err != nil {
-			err = fmt.Errorf("failed to list: %w", err)
+			return nil, nil, fmt.Errorf("verifier initialization: %w", err)
 		}
+		policy := ingrpc.Policy()
+		policy[healthv1.Health_Check_FullMethodName] = auth.RolePublic
+		policy[healthv1.Health_Watch_FullMethodName] = auth.RolePublic
+		unary = append(unary, auth.UnaryServerInterceptor(verifier, policy))
+		stream = append(stream, auth.StreamServerInterceptor(verifier, policy))
+	} else {
+		logger.Warn("auth interceptor disabled, any caller is allowed any call unauthenticated")
+	}
+	if cfg.Interceptors.Validation {
+		validator, err := protovalidate.New()
+		if err != nil {
+			return nil, nil, fmt.Errorf("validator initialization: %w", err)
+		}
+		unary = append(unary, validation.UnaryServerInterceptor(validator))
+		stream = append(stream, validation.StreamServerInterceptor(validator))
+	}
+	return unary, stream, nil
+}
 
-		if listErr := srv.Serve(lis); listErr != nil {
-			err = fmt.Errorf("failed to serve: %w", listErr)
+// serveGRPC serves the gRPC server on the address until it is stopped.
+func serveGRPC(srv *grpc.Server, addr string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %w", err)
 		}
+	}()
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		err = fmt.Errorf("failed to list: %w", err)
+	}
 
-		return err
+	if listErr := srv.Serve(lis); listErr != nil {
+		err = fmt.Errorf("failed to serve: %w", listErr)
+	}
+
+	return err
+}
+
+// newRegistry returns the registry of the metrics, along with the ones of the
+// Go runtime and of the process.
+func newRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	return reg
+}
+
+// startMetrics registers the gauges of the store and serves the metrics on
+// the address in the group, nil when it is empty.
+func startMetrics(grp *errgroup.Group, addr string, reg *prometheus.Registry, store metrics.StatsReader) (*http.Server, error) {
+	if err := metrics.RegisterStore(reg, store); err != nil {
+		return nil, fmt.Errorf("store metrics: %w", err)
+	}
+	if addr == "" {
+		return nil, nil //nolint:nilnil // The metrics are disabled.
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}))
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	grp.Go(func() error {
+		return serveHTTP(srv, "metrics server")
 	})
+	return srv, nil
+}
+
+// newLogger returns the logger of the server, the configuration is valid.
+func newLogger(cfg config.Log) *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+	opts := &slog.HandlerOptions{Level: level}
+	if cfg.Format == config.LogJSON {
+		return slog.New(slog.NewJSONHandler(os.Stderr, opts))
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, opts))
+}
 
+// isEditor reports whether the caller is an editor, who may list the
+// unpublished news and read the news under embargo.
+func isEditor(ctx context.Context) bool {
+	identity, ok := auth.FromContext(ctx)
+	return ok && identity.Role >= auth.RoleEditor
+}
+
+// subject returns the subject of the token of the caller, or else the common
+// name of its verified client certificate, empty when unauthenticated.
+func subject(ctx context.Context) string {
+	if identity, ok := auth.FromContext(ctx); ok {
+		return identity.Subject
+	}
+	if cert, ok := certs.PeerCertificate(ctx); ok {
+		return cert.Subject.CommonName
+	}
+	return ""
+}
+
+// serverOptions returns the options of the gRPC server.
+func serverOptions(
+	cfg config.GRPC, unary []grpc.UnaryServerInterceptor, stream []grpc.StreamServerInterceptor,
+) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+		grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize),
+		grpc.MaxSendMsgSize(cfg.MaxSendMsgSize),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    cfg.Keepalive.Time,
+			Timeout: cfg.Keepalive.Timeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.Keepalive.MinTime,
+			PermitWithoutStream: cfg.Keepalive.PermitWithoutStream,
+		}),
+	}
+}
+
+// newReloader returns the reloader of the certificates of the servers, nil
+// when TLS is disabled.
+func newReloader(cfg config.TLS) (*certs.Reloader, error) {
+	if !cfg.Enabled() {
+		return nil, nil //nolint:nilnil // The servers run in plaintext.
+	}
+	reloader, err := certs.NewReloader(certs.Files{CertFile: cfg.CertFile, KeyFile: cfg.KeyFile, CAFile: cfg.ClientCAFile})
+	if err != nil {
+		return nil, fmt.Errorf("load certificates: %w", err)
+	}
+	return reloader, nil
+}
+
+// credentialsOptions returns the options serving the gRPC server over TLS,
+// none without a reloader.
+func credentialsOptions(reloader *certs.Reloader) []grpc.ServerOption {
+	if reloader == nil {
+		return nil
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(reloader.ServerConfig()))}
+}
+
+// serverTLSConfig returns the TLS configuration of an HTTP server, nil
+// without a reloader.
+func serverTLSConfig(reloader *certs.Reloader) *tls.Config {
+	if reloader == nil {
+		return nil
+	}
+	return reloader.ServerConfig()
+}
+
+// startGateway serves the JSON/HTTP gateway of the local gRPC server in the
+// group.
+func startGateway(
+	ctx context.Context, grp *errgroup.Group, cfg *config.Config, local *grpc.Server, tlsConfig *tls.Config,
+) (*http.Server, error) {
+	lis := bufconn.Listen(localBufferSize)
+	srv, err := newGatewayServer(ctx, cfg.Gateway.Addr, lis, cfg.GRPC)
+	if err != nil {
+		return nil, err
+	}
+	srv.TLSConfig = tlsConfig
 	grp.Go(func() error {
-		defer func() {
-			if r := recover(); r != nil {
-				err = fmt.Errorf("panic: %w", err)
-			}
-		}()
-		interceptSignals(grpCtx)
-		healthSrv.Shutdown()
-		return shutdown(grpCtx, srv)
+		if err := local.Serve(lis); err != nil {
+			return fmt.Errorf("failed to serve gateway connections: %w", err)
+		}
+		return nil
+	})
+	grp.Go(func() error {
+		return serveHTTP(srv, "gateway")
 	})
+	return srv, nil
+}
+
+// shutdownGateway gracefully shuts the gateway down along with its local gRPC
+// server, when enabled.
+func shutdownGateway(ctx context.Context, srv *http.Server, local *grpc.Server) error {
+	if srv == nil {
+		return nil
+	}
+	shutdownHTTP(ctx, srv, "gateway")
+	return shutdown(ctx, local)
+}
 
-	if err := grp.Wait(); err != nil {
-		log.Fatal("server shutdown", err)
+// localBufferSize of the in-process connection of the gateway.
+const localBufferSize = 1 << 20
+
+// newGatewayServer returns the HTTP server of the JSON/HTTP gateway of the
+// gRPC server of the in-process listener.
+func newGatewayServer(ctx context.Context, addr string, lis *bufconn.Listener, grpcCfg config.GRPC) (*http.Server, error) {
+	conn, err := grpc.NewClient("passthrough:///local",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(grpcCfg.MaxSendMsgSize),
+			grpc.MaxCallSendMsgSize(grpcCfg.MaxRecvMsgSize),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dial grpc server: %w", err)
+	}
+	handler, err := gateway.New(ctx, conn)
+	if err != nil {
+		return nil, errors.Join(err, conn.Close())
+	}
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	srv.RegisterOnShutdown(func() {
+		if closeErr := conn.Close(); closeErr != nil {
+			log.Printf("gateway connection close: %v", closeErr)
+		}
+	})
+	return srv, nil
+}
+
+// newConnectServer returns the HTTP server of the Connect handler, serving
+// HTTP/1.1 and HTTP/2 as gRPC requires HTTP/2, without TLS (h2c) when there is
+// no TLS configuration. Browsers of the origins are allowed to call it.
+func newConnectServer(addr, path string, handler http.Handler, origins []string, tlsConfig *tls.Config) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle(path, inconnect.PeerHandler(handler))
+
+	var corsHandler http.Handler = mux
+	if len(origins) > 0 {
+		corsHandler = newCORS(origins).Handler(mux)
+	}
+
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	if tlsConfig != nil {
+		protocols.SetHTTP2(true)
+	} else {
+		protocols.SetUnencryptedHTTP2(true)
+	}
+	return &http.Server{
+		Addr:              addr,
+		Handler:           corsHandler,
+		Protocols:         protocols,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// newCORS allows the browsers of the origins to call the server with the
+// Connect and gRPC-Web protocols.
+func newCORS(origins []string) *cors.Cors {
+	return cors.New(cors.Options{
+		AllowedOrigins: origins,
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders: []string{
+			"Authorization", "Content-Type", "Connect-Protocol-Version", "Connect-Timeout-Ms",
+			"Grpc-Timeout", "X-Grpc-Web", "X-User-Agent", "X-Request-Id",
+		},
+		ExposedHeaders: []string{"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin", "X-Request-Id"},
+		MaxAge:         int((2 * time.Hour).Seconds()),
+	})
+}
+
+// serveHTTP serves the HTTP server until it is shut down, over TLS when it
+// has a TLS configuration.
+func serveHTTP(srv *http.Server, name string) error {
+	serve := srv.ListenAndServe
+	if srv.TLSConfig != nil {
+		// The certificates come from the configuration.
+		serve = func() error { return srv.ListenAndServeTLS("", "") }
+	}
+	if err := serve(); !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve %s: %w", name, err)
+	}
+	return nil
+}
+
+// shutdownHTTP gracefully shuts the HTTP server down, when enabled.
+func shutdownHTTP(ctx context.Context, srv *http.Server, name string) {
+	if srv == nil {
+		return
+	}
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("%s shutdown: %v", name, err)
+	}
+}
+
+// newsStore serves the RPCs and publishes the scheduled news.
+type newsStore interface {
+	ingrpc.NewsStorer
+	schedule.Releaser
+	metrics.StatsReader
+}
+
+// newStore returns the news store of the given kind along with a function
+// releasing it.
+//
+//nolint:ireturn // The backend is picked at runtime.
+func newStore(cfg config.Store, opts ...memstore.Option) (newsStore, func() error, error) {
+	switch cfg.Backend {
+	case config.StoreMemory:
+		return memstore.New(opts...), func() error { return nil }, nil
+	case config.StoreDisk:
+		store, err := diskstore.Open(diskstore.Config{Dir: cfg.Dir, SnapshotEvery: cfg.SnapshotEvery}, opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open disk store: %w", err)
+		}
+		return store, store.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown store %q", cfg.Backend)
 	}
 }
 
@@ -0,0 +1,149 @@
+// Package auth authenticates the callers with the JWT bearer tokens of their
+// calls, verified against the keys of a JWKS file, and authorizes the calls
+// with the roles of the tokens.
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+// Role of a caller, each role is granted the methods of the lower ones.
+type Role int
+
+// Roles from the lowest to the highest.
+const (
+	// RolePublic is required by the methods callable without a token.
+	RolePublic Role = iota
+	RoleReader
+	RoleEditor
+	RoleAdmin
+)
+
+// roleNames of the roles in the tokens.
+var roleNames = map[string]Role{
+	"reader": RoleReader,
+	"editor": RoleEditor,
+	"admin":  RoleAdmin,
+}
+
+func (r Role) String() string {
+	for name, role := range roleNames {
+		if role == r {
+			return name
+		}
+	}
+	return "public"
+}
+
+// Policy maps the full method names, such as /news.v1.NewsService/Get, to
+// the lowest role allowed to call them. Methods out of the policy are denied.
+type Policy map[string]Role
+
+// Identity of an authenticated caller.
+type Identity struct {
+	// Subject of the token.
+	Subject string
+	// Role of the caller, the highest of the roles of the token.
+	Role Role
+}
+
+type identityKey struct{}
+
+// NewContext returns the context of a call of the identity.
+//
+//nolint:ireturn // The context is derived.
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext returns the identity of the caller, false when the call is not
+// authenticated.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok
+}
+
+// algorithms of the signatures of the tokens.
+var algorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// claims of the tokens.
+type claims struct {
+	jwt.Claims
+	Roles []string `json:"roles"`
+}
+
+// Verifier of the tokens.
+type Verifier struct {
+	keys     *jose.JSONWebKeySet
+	issuer   string
+	audience string
+}
+
+// NewVerifier returns a verifier of the tokens signed by the keys of the JWKS
+// file. The issuer and the audience of the tokens are checked when set.
+func NewVerifier(jwksFile, issuer, audience string) (*Verifier, error) {
+	raw, err := os.ReadFile(jwksFile)
+	if err != nil {
+		return nil, fmt.Errorf("read JWKS: %w", err)
+	}
+	keys := &jose.JSONWebKeySet{}
+	if err := json.Unmarshal(raw, keys); err != nil {
+		return nil, fmt.Errorf("decode JWKS %s: %w", jwksFile, err)
+	}
+	if len(keys.Keys) == 0 {
+		return nil, fmt.Errorf("no key in JWKS %s", jwksFile)
+	}
+	for i := range keys.Keys {
+		if !keys.Keys[i].IsPublic() {
+			return nil, fmt.Errorf("JWKS %s holds the private key %q", jwksFile, keys.Keys[i].KeyID)
+		}
+	}
+	return &Verifier{keys: keys, issuer: issuer, audience: audience}, nil
+}
+
+// Verify the token, returning the identity of its subject.
+func (v *Verifier) Verify(token string) (*Identity, error) {
+	parsed, err := jwt.ParseSigned(token, algorithms)
+	if err != nil {
+		return nil, fmt.Errorf("parse token: %w", err)
+	}
+	var c claims
+	if err := parsed.Claims(v.keys, &c); err != nil {
+		return nil, fmt.Errorf("verify token: %w", err)
+	}
+
+	expected := jwt.Expected{Issuer: v.issuer, Time: time.Now()}
+	if v.audience != "" {
+		expected.AnyAudience = jwt.Audience{v.audience}
+	}
+	if err := c.ValidateWithLeeway(expected, jwt.DefaultLeeway); err != nil {
+		return nil, fmt.Errorf("validate token: %w", err)
+	}
+	if c.Expiry == nil {
+		return nil, errors.New("token without expiry")
+	}
+	if c.Subject == "" {
+		return nil, errors.New("token without subject")
+	}
+
+	identity := &Identity{Subject: c.Subject}
+	for _, name := range c.Roles {
+		if role := roleNames[name]; role > identity.Role {
+			identity.Role = role
+		}
+	}
+	return identity, nil
+}
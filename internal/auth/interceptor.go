@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor authorizes the unary calls with the policy.
+func UnaryServerInterceptor(verifier *Verifier, policy Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(ctx, verifier, policy, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor authorizes the streaming calls with the policy.
+func StreamServerInterceptor(verifier *Verifier, policy Policy) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(stream.Context(), verifier, policy, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authorize the call of the method, returning its context with the identity
+// of the caller. A token is verified whenever there is one, even for the
+// public methods.
+//
+//nolint:ireturn // The context is derived.
+func authorize(ctx context.Context, verifier *Verifier, policy Policy, method string) (context.Context, error) {
+	required, ok := policy[method]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "method %s is not allowed", method)
+	}
+	token, ok := bearerToken(ctx)
+	if !ok {
+		if required == RolePublic {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token") //nolint:wrapcheck // Status errors are returned as is.
+	}
+	identity, err := verifier.Verify(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error()) //nolint:wrapcheck // Status errors are returned as is.
+	}
+	if identity.Role < required {
+		return nil, status.Errorf(codes.PermissionDenied, "method %s requires the %s role", method, required)
+	}
+	return NewContext(ctx, identity), nil
+}
+
+// bearerToken returns the token of the authorization metadata of the call.
+func bearerToken(ctx context.Context) (string, bool) {
+	values := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(values) == 0 {
+		return "", false
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// serverStream carries the context of the authorized call.
+type serverStream struct {
+	grpc.ServerStream
+	//nolint:containedctx // The context of the stream is replaced.
+	ctx context.Context
+}
+
+//nolint:ireturn // Required by grpc.ServerStream.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
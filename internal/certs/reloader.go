@@ -0,0 +1,169 @@
+// Package certs loads the TLS certificates of the server from disk and
+// reloads them when they change, so that they are rotated without a restart.
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Files of a certificate and of its key, along with the CA bundle verifying
+// the certificates of the peers.
+type Files struct {
+	CertFile string
+	KeyFile  string
+	// CAFile is optional, the peers are not verified by the server without it.
+	CAFile string
+}
+
+// Reloader serves the latest certificate and CA bundle read from the files.
+type Reloader struct {
+	files Files
+
+	lock     sync.RWMutex
+	cert     *tls.Certificate
+	pool     *x509.CertPool
+	modTimes []time.Time
+}
+
+// NewReloader returns a reloader of the files, failing when they cannot be
+// loaded.
+func NewReloader(files Files) (*Reloader, error) {
+	r := &Reloader{files: files}
+	if _, err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Run checks the files for changes at every interval until the context is
+// done. A failed reload keeps the previous certificates.
+func (r *Reloader) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		reloaded, err := r.reload()
+		switch {
+		case err != nil:
+			log.Printf("certs: %v", err)
+		case reloaded:
+			log.Printf("certs: reloaded %s", r.files.CertFile)
+		}
+	}
+}
+
+// ServerConfig returns the TLS configuration of a server. The clients must
+// present a certificate verified by the CA bundle when there is one.
+func (r *Reloader) ServerConfig() *tls.Config {
+	cfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.getCertificate,
+	}
+	if r.files.CAFile != "" {
+		// The bundle may change, the certificates are verified against the
+		// latest one rather than a fixed ClientCAs pool. Unlike
+		// VerifyPeerCertificate, VerifyConnection also runs on resumed
+		// sessions.
+		cfg.ClientAuth = tls.RequireAnyClientCert
+		cfg.VerifyConnection = r.verifyClient
+	}
+	return cfg
+}
+
+func (r *Reloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.cert, nil
+}
+
+// verifyClient verifies the certificate chain of the client of the connection
+// against the CA bundle.
+func (r *Reloader) verifyClient(state tls.ConnectionState) error { //nolint:gocritic // Signature of tls.Config.VerifyConnection.
+	certs := state.PeerCertificates
+	if len(certs) == 0 {
+		return errors.New("no client certificate")
+	}
+
+	r.lock.RLock()
+	pool := r.pool
+	r.lock.RUnlock()
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return fmt.Errorf("verify client certificate: %w", err)
+	}
+	return nil
+}
+
+// reload the files when one of them changed since the last load, it reports
+// whether they were.
+func (r *Reloader) reload() (bool, error) {
+	paths := []string{r.files.CertFile, r.files.KeyFile}
+	if r.files.CAFile != "" {
+		paths = append(paths, r.files.CAFile)
+	}
+	modTimes := make([]time.Time, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return false, fmt.Errorf("stat %s: %w", path, err)
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+	r.lock.RLock()
+	unchanged := slices.EqualFunc(modTimes, r.modTimes, time.Time.Equal)
+	r.lock.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.files.CertFile, r.files.KeyFile)
+	if err != nil {
+		return false, fmt.Errorf("load key pair: %w", err)
+	}
+	var pool *x509.CertPool
+	if r.files.CAFile != "" {
+		if pool, err = LoadPool(r.files.CAFile); err != nil {
+			return false, err
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.cert = &cert
+	r.pool = pool
+	r.modTimes = modTimes
+	return true, nil
+}
+
+// LoadPool returns the pool of the certificates of the PEM bundle.
+func LoadPool(path string) (*x509.CertPool, error) {
+	bundle, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, fmt.Errorf("no certificate in CA bundle %s", path)
+	}
+	return pool, nil
+}
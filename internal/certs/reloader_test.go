@@ -0,0 +1,423 @@
+package certs_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/codeandlearn1991/news-grpc/internal/certs"
+	"github.com/codeandlearn1991/news-grpc/internal/connect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// testCA issues the certificates of the tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	// file of the PEM certificate of the CA.
+	file string
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	writePEM(t, file, "CERTIFICATE", der)
+	return &testCA{cert: cert, key: key, file: file}
+}
+
+// issue a certificate of the common name for the usage, returning the files
+// of the certificate and of its key.
+func (ca *testCA) issue(t *testing.T, commonName string, usage x509.ExtKeyUsage) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv6loopback, net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+	return certFile, keyFile
+}
+
+func writePEM(t *testing.T, file, blockType string, der []byte) {
+	t.Helper()
+
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newMutualTLS returns the reloader of a server certificate of the CA, asking
+// for client certificates of the CA.
+func newMutualTLS(t *testing.T, ca *testCA) *certs.Reloader {
+	t.Helper()
+
+	certFile, keyFile := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	reloader, err := certs.NewReloader(certs.Files{CertFile: certFile, KeyFile: keyFile, CAFile: ca.file})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reloader
+}
+
+// clientConfig returns the TLS configuration of a client trusting the server
+// CA and presenting a certificate of the client CA with the common name.
+func clientConfig(t *testing.T, serverCA, clientCA *testCA, commonName string) *tls.Config {
+	t.Helper()
+
+	certFile, keyFile := clientCA.issue(t, commonName, x509.ExtKeyUsageClientAuth)
+	cfg, err := certs.ClientConfig(certs.Files{CertFile: certFile, KeyFile: keyFile, CAFile: serverCA.file}, "localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+// peerName is the name of the client certificate seen by the handlers of the
+// servers, or "none".
+func peerName(ctx context.Context) string {
+	if cert, ok := certs.PeerCertificate(ctx); ok {
+		return cert.Subject.CommonName
+	}
+	return "none"
+}
+
+// serveGRPC serves a gRPC server with the TLS configuration, whose handler
+// answers every call with the name of the client certificate. It returns the
+// address of the server.
+func serveGRPC(t *testing.T, cfg *tls.Config) string {
+	t.Helper()
+
+	srv := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(cfg)),
+		grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+			if err := stream.RecvMsg(&emptypb.Empty{}); err != nil {
+				return fmt.Errorf("receive request: %w", err)
+			}
+			if err := stream.SendMsg(wrapperspb.String(peerName(stream.Context()))); err != nil {
+				return fmt.Errorf("send response: %w", err)
+			}
+			return nil
+		}),
+	)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(lis) }()
+	t.Cleanup(func() {
+		srv.Stop()
+		if err := <-served; err != nil {
+			t.Errorf("Serve() error = %v", err)
+		}
+	})
+	return lis.Addr().String()
+}
+
+// callGRPC returns the name of the client certificate seen by the gRPC
+// server.
+func callGRPC(ctx context.Context, addr string, cfg *tls.Config) (string, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(cfg)))
+	if err != nil {
+		return "", fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	res := &wrapperspb.StringValue{}
+	if err := conn.Invoke(ctx, "/test.Peer/Name", &emptypb.Empty{}, res); err != nil {
+		return "", fmt.Errorf("invoke: %w", err)
+	}
+	return res.GetValue(), nil
+}
+
+// serveConnect serves an HTTP server with the TLS configuration, whose
+// handler answers every request with the name of the client certificate, as
+// the Connect server does. It returns the address of the server.
+func serveConnect(t *testing.T, cfg *tls.Config) string {
+	t.Helper()
+
+	srv := &http.Server{
+		Handler: connect.PeerHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, err := io.WriteString(w, peerName(r.Context())); err != nil {
+				t.Errorf("write response: %v", err)
+			}
+		})),
+		TLSConfig:         cfg,
+		ReadHeaderTimeout: time.Second,
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	served := make(chan error, 1)
+	go func() { served <- srv.ServeTLS(lis, "", "") }()
+	t.Cleanup(func() {
+		if err := srv.Close(); err != nil {
+			t.Errorf("Close() error = %v", err)
+		}
+		if err := <-served; !errors.Is(err, http.ErrServerClosed) {
+			t.Errorf("ServeTLS() error = %v, want %v", err, http.ErrServerClosed)
+		}
+	})
+	return lis.Addr().String()
+}
+
+// callConnect returns the name of the client certificate seen by the HTTP
+// server.
+func callConnect(ctx context.Context, addr string, cfg *tls.Config) (string, error) {
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+	defer client.CloseIdleConnections()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+addr, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("new request: %w", err)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("do request: %w", err)
+	}
+	defer res.Body.Close()
+
+	name, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("read response: %w", err)
+	}
+	return string(name), nil
+}
+
+// paths serving the calls, by the servers of the tests.
+var paths = []struct {
+	name  string
+	serve func(t *testing.T, cfg *tls.Config) string
+	call  func(ctx context.Context, addr string, cfg *tls.Config) (string, error)
+}{
+	{"grpc", serveGRPC, callGRPC},
+	{"connect", serveConnect, callConnect},
+}
+
+func TestPeerCertificate(t *testing.T) {
+	ca := newTestCA(t, "ca")
+	reloader := newMutualTLS(t, ca)
+
+	for _, path := range paths {
+		t.Run(path.name, func(t *testing.T) {
+			addr := path.serve(t, reloader.ServerConfig())
+
+			got, err := path.call(t.Context(), addr, clientConfig(t, ca, ca, "alice"))
+			if err != nil {
+				t.Fatalf("call error = %v", err)
+			}
+			if got != "alice" {
+				t.Errorf("handler saw client certificate %q, want %q", got, "alice")
+			}
+		})
+	}
+}
+
+func TestPeerCertificateWithoutTLS(t *testing.T) {
+	if _, ok := certs.PeerCertificate(t.Context()); ok {
+		t.Error("PeerCertificate() found a certificate outside of a call")
+	}
+}
+
+func TestMutualTLSRejections(t *testing.T) {
+	ca := newTestCA(t, "ca")
+	reloader := newMutualTLS(t, ca)
+
+	for _, tc := range []struct {
+		name   string
+		client func(t *testing.T) *tls.Config
+	}{
+		{
+			name: "unknown CA",
+			client: func(t *testing.T) *tls.Config {
+				t.Helper()
+				return clientConfig(t, ca, newTestCA(t, "other"), "mallory")
+			},
+		},
+		{
+			name: "server certificate",
+			client: func(t *testing.T) *tls.Config {
+				t.Helper()
+				certFile, keyFile := ca.issue(t, "mallory", x509.ExtKeyUsageServerAuth)
+				cfg, err := certs.ClientConfig(certs.Files{CertFile: certFile, KeyFile: keyFile, CAFile: ca.file}, "localhost")
+				if err != nil {
+					t.Fatal(err)
+				}
+				return cfg
+			},
+		},
+		{
+			name: "no certificate",
+			client: func(t *testing.T) *tls.Config {
+				t.Helper()
+				cfg, err := certs.ClientConfig(certs.Files{CAFile: ca.file}, "localhost")
+				if err != nil {
+					t.Fatal(err)
+				}
+				return cfg
+			},
+		},
+	} {
+		for _, path := range paths {
+			t.Run(tc.name+" over "+path.name, func(t *testing.T) {
+				addr := path.serve(t, reloader.ServerConfig())
+
+				if got, err := path.call(t.Context(), addr, tc.client(t)); err == nil {
+					t.Errorf("call succeeded with handler seeing %q, want an error", got)
+				}
+			})
+		}
+	}
+}
+
+// servedName returns the common name of the certificate the configuration
+// serves.
+func servedName(t *testing.T, cfg *tls.Config) string {
+	t.Helper()
+
+	cert, err := cfg.GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf.Subject.CommonName
+}
+
+// replace the file with the content of the other one, dated in the future so
+// that the change is seen whatever the resolution of the modification times.
+func replace(t *testing.T, file, other string) {
+	t.Helper()
+
+	data, err := os.ReadFile(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(file, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err = os.Chtimes(file, later, later); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReloaderRun(t *testing.T) {
+	ca := newTestCA(t, "ca")
+	certFile, keyFile := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	reloader, err := certs.NewReloader(certs.Files{CertFile: certFile, KeyFile: keyFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := reloader.ServerConfig()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		reloader.Run(ctx, time.Millisecond)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// waitFor the certificate of the common name to be served.
+	waitFor := func(want string) {
+		t.Helper()
+
+		deadline := time.Now().Add(5 * time.Second)
+		for servedName(t, cfg) != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("served certificate %q, want %q", servedName(t, cfg), want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	rotatedCert, rotatedKey := ca.issue(t, "rotated", x509.ExtKeyUsageServerAuth)
+	replace(t, keyFile, rotatedKey)
+	replace(t, certFile, rotatedCert)
+	waitFor("rotated")
+
+	// A file that fails to load keeps the previous certificate.
+	if err = os.WriteFile(certFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	broken := time.Now().Add(2 * time.Minute)
+	if err = os.Chtimes(certFile, broken, broken); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if got := servedName(t, cfg); got != "rotated" {
+		t.Errorf("served certificate %q after a failed reload, want %q", got, "rotated")
+	}
+}
@@ -0,0 +1,230 @@
+// Package config holds the configuration of the server. It is read from the
+// command line flags, the environment variables and a YAML file, in that
+// order of precedence, over the defaults. The variables are prefixed with
+// NEWS_, those of the prefix naming no setting are ignored with a warning.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Store backends.
+const (
+	StoreMemory = "memory"
+	StoreDisk   = "disk"
+)
+
+// Log formats.
+const (
+	LogText = "text"
+	LogJSON = "json"
+)
+
+// Config of the server.
+type Config struct {
+	GRPC         GRPC         `yaml:"grpc"`
+	TLS          TLS          `yaml:"tls"`
+	Auth         Auth         `yaml:"auth"`
+	Gateway      Gateway      `yaml:"gateway"`
+	Connect      Connect      `yaml:"connect"`
+	Metrics      Metrics      `yaml:"metrics"`
+	Store        Store        `yaml:"store"`
+	Trash        Trash        `yaml:"trash"`
+	Shutdown     Shutdown     `yaml:"shutdown"`
+	Log          Log          `yaml:"log"`
+	Interceptors Interceptors `yaml:"interceptors"`
+}
+
+// GRPC server configuration.
+type GRPC struct {
+	// Addr the gRPC server listens on.
+	Addr string `yaml:"addr"`
+	// MaxRecvMsgSize in bytes of the received messages.
+	MaxRecvMsgSize int `yaml:"max_recv_msg_size"`
+	// MaxSendMsgSize in bytes of the sent messages.
+	MaxSendMsgSize int `yaml:"max_send_msg_size"`
+	// Keepalive of the connections.
+	Keepalive Keepalive `yaml:"keepalive"`
+}
+
+// Keepalive of the gRPC connections.
+type Keepalive struct {
+	// Time after which an idle connection is pinged.
+	Time time.Duration `yaml:"time"`
+	// Timeout after which a ping without answer closes the connection.
+	Timeout time.Duration `yaml:"timeout"`
+	// MinTime the clients must wait between their pings.
+	MinTime time.Duration `yaml:"min_time"`
+	// PermitWithoutStream allows the clients to ping without active calls.
+	PermitWithoutStream bool `yaml:"permit_without_stream"`
+}
+
+// TLS configuration of the servers, which run in plaintext without a
+// certificate.
+type TLS struct {
+	// CertFile of the PEM certificate of the servers.
+	CertFile string `yaml:"cert_file"`
+	// KeyFile of the PEM key of the certificate.
+	KeyFile string `yaml:"key_file"`
+	// ClientCAFile of the PEM bundle verifying the certificates of the
+	// clients. The clients must present one when it is set (mTLS).
+	ClientCAFile string `yaml:"client_ca_file"`
+	// ReloadInterval between the checks of the files for changes.
+	ReloadInterval time.Duration `yaml:"reload_interval"`
+}
+
+// Enabled reports whether the servers use TLS.
+func (t *TLS) Enabled() bool {
+	return t.CertFile != ""
+}
+
+// Auth configuration of the JWT authentication of the callers.
+type Auth struct {
+	// JWKSFile of the keys verifying the tokens.
+	JWKSFile string `yaml:"jwks_file"`
+	// Issuer of the tokens, any when empty.
+	Issuer string `yaml:"issuer"`
+	// Audience the tokens must be issued for, any when empty.
+	Audience string `yaml:"audience"`
+}
+
+// Gateway configuration of the JSON/HTTP gateway.
+type Gateway struct {
+	// Addr the gateway listens on, empty to disable it.
+	Addr string `yaml:"addr"`
+}
+
+// Connect configuration of the server of the Connect, gRPC-Web and gRPC
+// protocols.
+type Connect struct {
+	// Addr the server listens on, empty to disable it.
+	Addr string `yaml:"addr"`
+	// CORSOrigins allowed to call the server from a browser, * for any.
+	CORSOrigins []string `yaml:"cors_origins"`
+}
+
+// Metrics configuration of the Prometheus endpoint.
+type Metrics struct {
+	// Addr the metrics are served on without TLS, empty to disable them.
+	Addr string `yaml:"addr"`
+}
+
+// Store configuration.
+type Store struct {
+	// Backend of the store, memory or disk.
+	Backend string `yaml:"backend"`
+	// Dir of the disk store.
+	Dir string `yaml:"dir"`
+	// SnapshotEvery writes the disk store takes a snapshot.
+	SnapshotEvery int `yaml:"snapshot_every"`
+}
+
+// Trash configuration.
+type Trash struct {
+	// Retention after which the soft deleted news are purged, zero keeps them
+	// forever.
+	Retention time.Duration `yaml:"retention"`
+	// Interval between the purges.
+	Interval time.Duration `yaml:"interval"`
+}
+
+// Shutdown configuration.
+type Shutdown struct {
+	// Timeout of the graceful shutdown, the calls still running after it are
+	// canceled.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// Log configuration of the server logs.
+type Log struct {
+	// Level of the logged records: debug, info, warn or error.
+	Level string `yaml:"level"`
+	// Format of the records, text or json.
+	Format string `yaml:"format"`
+	// Payloads of the unary calls are logged along with the calls.
+	Payloads bool `yaml:"payloads"`
+	// Redact the fields of the names from the logged payloads, at any depth.
+	Redact []string `yaml:"redact"`
+}
+
+// Interceptors toggles.
+type Interceptors struct {
+	// Metrics of the calls.
+	Metrics bool `yaml:"metrics"`
+	// Logging of every call as a structured record.
+	Logging bool `yaml:"logging"`
+	// Auth of the calls with the JWT bearer tokens of the callers, on by
+	// default. Without it any caller is allowed any call.
+	Auth bool `yaml:"auth"`
+	// Validation of the requests against their protovalidate rules.
+	Validation bool `yaml:"validation"`
+}
+
+// Default returns the default configuration.
+func Default() *Config {
+	return &Config{
+		GRPC: GRPC{
+			Addr:           ":50051",
+			MaxRecvMsgSize: 4 << 20,
+			MaxSendMsgSize: 4 << 20,
+			Keepalive: Keepalive{
+				Time:    2 * time.Hour,
+				Timeout: 20 * time.Second,
+				MinTime: 5 * time.Minute,
+			},
+		},
+		TLS:     TLS{ReloadInterval: time.Minute},
+		Gateway: Gateway{Addr: ":8080"},
+		Connect: Connect{Addr: ":8081"},
+		Metrics: Metrics{Addr: ":9090"},
+		Store: Store{
+			Backend:       StoreMemory,
+			Dir:           "data",
+			SnapshotEvery: 1000,
+		},
+		Trash: Trash{
+			Retention: 30 * 24 * time.Hour,
+			Interval:  time.Hour,
+		},
+		Shutdown:     Shutdown{Timeout: 30 * time.Second},
+		Log:          Log{Level: "info", Format: LogText},
+		Interceptors: Interceptors{Metrics: true, Logging: true, Auth: true, Validation: true},
+	}
+}
+
+// Validate the configuration, reporting every invalid value.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, key, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: "+format, append([]any{key}, args...)...))
+		}
+	}
+
+	check(c.GRPC.Addr != "", "grpc.addr", "must be set")
+	check(c.GRPC.MaxRecvMsgSize > 0, "grpc.max_recv_msg_size", "must be positive, got %d", c.GRPC.MaxRecvMsgSize)
+	check(c.GRPC.MaxSendMsgSize > 0, "grpc.max_send_msg_size", "must be positive, got %d", c.GRPC.MaxSendMsgSize)
+	check(c.GRPC.Keepalive.Time > 0, "grpc.keepalive.time", "must be positive, got %s", c.GRPC.Keepalive.Time)
+	check(c.GRPC.Keepalive.Timeout > 0, "grpc.keepalive.timeout", "must be positive, got %s", c.GRPC.Keepalive.Timeout)
+	check(c.GRPC.Keepalive.MinTime >= 0, "grpc.keepalive.min_time", "must not be negative, got %s", c.GRPC.Keepalive.MinTime)
+	check((c.TLS.CertFile == "") == (c.TLS.KeyFile == ""), "tls", "cert_file and key_file must be set together")
+	check(c.TLS.ClientCAFile == "" || c.TLS.Enabled(), "tls.client_ca_file", "requires cert_file")
+	check(c.TLS.ReloadInterval > 0, "tls.reload_interval", "must be positive, got %s", c.TLS.ReloadInterval)
+	check(!c.Interceptors.Metrics || c.Metrics.Addr != "", "metrics.addr", "must be set for the metrics interceptor")
+	check(!c.Interceptors.Auth || c.Auth.JWKSFile != "", "auth.jwks_file",
+		"must be set for the auth interceptor, unless interceptors.auth is disabled")
+	check(c.Store.Backend == StoreMemory || c.Store.Backend == StoreDisk,
+		"store.backend", "must be %s or %s, got %q", StoreMemory, StoreDisk, c.Store.Backend)
+	check(c.Store.Backend != StoreDisk || c.Store.Dir != "", "store.dir", "must be set for the disk store")
+	check(c.Store.SnapshotEvery > 0, "store.snapshot_every", "must be positive, got %d", c.Store.SnapshotEvery)
+	check(c.Trash.Retention >= 0, "trash.retention", "must not be negative, got %s", c.Trash.Retention)
+	check(c.Trash.Retention == 0 || c.Trash.Interval > 0, "trash.interval", "must be positive, got %s", c.Trash.Interval)
+	check(c.Shutdown.Timeout > 0, "shutdown.timeout", "must be positive, got %s", c.Shutdown.Timeout)
+	var level slog.Level
+	check(level.UnmarshalText([]byte(c.Log.Level)) == nil, "log.level", "must be debug, info, warn or error, got %q", c.Log.Level)
+	check(c.Log.Format == LogText || c.Log.Format == LogJSON, "log.format", "must be %s or %s, got %q", LogText, LogJSON, c.Log.Format)
+	return errors.Join(errs...)
+}
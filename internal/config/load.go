@@ -0,0 +1,247 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envPrefix of the environment variables of the configuration.
+const envPrefix = "NEWS_"
+
+// configEnv names the configuration file in the environment.
+const configEnv = envPrefix + "CONFIG"
+
+// setting of the configuration, bound to its field.
+type setting struct {
+	// key of the setting in the file, such as grpc.addr.
+	key   string
+	flag  string
+	usage string
+	value flag.Value
+}
+
+// env returns the environment variable of the setting, such as
+// NEWS_GRPC_ADDR.
+func (s *setting) env() string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(s.key, ".", "_"))
+}
+
+// settings returns the settings bound to the fields of the configuration.
+func (c *Config) settings() []*setting {
+	return []*setting{
+		{"grpc.addr", "grpc-addr", "address the gRPC server listens on", stringVar(&c.GRPC.Addr)},
+		{"grpc.max_recv_msg_size", "max-recv-msg-size", "maximum size in bytes of the received messages", intVar(&c.GRPC.MaxRecvMsgSize)},
+		{"grpc.max_send_msg_size", "max-send-msg-size", "maximum size in bytes of the sent messages", intVar(&c.GRPC.MaxSendMsgSize)},
+		{"grpc.keepalive.time", "keepalive-time", "time after which an idle connection is pinged", durationVar(&c.GRPC.Keepalive.Time)},
+		{"grpc.keepalive.timeout", "keepalive-timeout", "time after which an unanswered ping closes the connection", durationVar(&c.GRPC.Keepalive.Timeout)},
+		{"grpc.keepalive.min_time", "keepalive-min-time", "minimum time the clients wait between their pings", durationVar(&c.GRPC.Keepalive.MinTime)},
+		{
+			"grpc.keepalive.permit_without_stream", "keepalive-permit-without-stream",
+			"allow the clients to ping without active calls", boolVar(&c.GRPC.Keepalive.PermitWithoutStream),
+		},
+		{"tls.cert_file", "tls-cert-file", "PEM certificate of the servers, plaintext without it", stringVar(&c.TLS.CertFile)},
+		{"tls.key_file", "tls-key-file", "PEM key of the certificate", stringVar(&c.TLS.KeyFile)},
+		{"tls.client_ca_file", "tls-client-ca-file", "PEM bundle verifying the client certificates, required with it", stringVar(&c.TLS.ClientCAFile)},
+		{"tls.reload_interval", "tls-reload-interval", "interval between the checks of the TLS files for changes", durationVar(&c.TLS.ReloadInterval)},
+		{"auth.jwks_file", "auth-jwks-file", "JWKS file of the keys verifying the bearer tokens", stringVar(&c.Auth.JWKSFile)},
+		{"auth.issuer", "auth-issuer", "issuer of the bearer tokens, any when empty", stringVar(&c.Auth.Issuer)},
+		{"auth.audience", "auth-audience", "audience the bearer tokens must be issued for, any when empty", stringVar(&c.Auth.Audience)},
+		{"gateway.addr", "http-addr", "address of the JSON/HTTP gateway, empty to disable it", stringVar(&c.Gateway.Addr)},
+		{
+			"connect.addr", "connect-addr",
+			"address serving the Connect, gRPC-Web and gRPC protocols over h2c, empty to disable it", stringVar(&c.Connect.Addr),
+		},
+		{"connect.cors_origins", "cors-origins", "comma separated origins allowed to call the Connect server, * for any", listVar(&c.Connect.CORSOrigins)},
+		{"metrics.addr", "metrics-addr", "address of the Prometheus metrics, served without TLS, empty to disable them", stringVar(&c.Metrics.Addr)},
+		{"store.backend", "store", "news store backend, memory or disk", stringVar(&c.Store.Backend)},
+		{"store.dir", "data-dir", "directory of the disk store", stringVar(&c.Store.Dir)},
+		{"store.snapshot_every", "snapshot-every", "writes after which the disk store takes a snapshot", intVar(&c.Store.SnapshotEvery)},
+		{"trash.retention", "trash-retention", "period after which soft deleted news are purged, 0 keeps them forever", durationVar(&c.Trash.Retention)},
+		{"trash.interval", "retention-interval", "interval between the purges of the trash", durationVar(&c.Trash.Interval)},
+		{"shutdown.timeout", "shutdown-timeout", "timeout of the graceful shutdown", durationVar(&c.Shutdown.Timeout)},
+		{"log.level", "log-level", "level of the logged records: debug, info, warn or error", stringVar(&c.Log.Level)},
+		{"log.format", "log-format", "format of the logged records, text or json", stringVar(&c.Log.Format)},
+		{"log.payloads", "log-payloads", "log the payloads of the unary calls", boolVar(&c.Log.Payloads)},
+		{"log.redact", "log-redact", "comma separated names of the fields redacted from the logged payloads", listVar(&c.Log.Redact)},
+		{"interceptors.metrics", "metrics", "measure the calls as Prometheus metrics", boolVar(&c.Interceptors.Metrics)},
+		{"interceptors.logging", "logging", "log every call as a structured record", boolVar(&c.Interceptors.Logging)},
+		{"interceptors.auth", "auth", "authenticate and authorize the calls by JWT role, disabling it allows any call to anyone", boolVar(&c.Interceptors.Auth)},
+		{"interceptors.validation", "validation", "validate the requests against their protovalidate rules", boolVar(&c.Interceptors.Validation)},
+	}
+}
+
+// Load the configuration from the command line arguments, the environment
+// variables and the YAML file named by the -config flag or by NEWS_CONFIG.
+// The flags take precedence over the environment, which takes precedence
+// over the file. Unknown flags and keys fail the load, unknown variables are
+// only logged.
+func Load(name string, args, environ []string) (*Config, error) {
+	cfg := Default()
+	settings := cfg.settings()
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	path := fs.String("config", "", "YAML configuration file, "+configEnv+" in the environment")
+	for _, s := range settings {
+		fs.Var(s.value, s.flag, fmt.Sprintf("%s (%s, %s)", s.usage, s.key, s.env()))
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("parse flags: %w", err)
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments %q", fs.Args())
+	}
+	// The flags are set again over the file and the environment.
+	flags := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		flags[f.Name] = f.Value.String()
+	})
+
+	env := make(map[string]string)
+	for _, kv := range environ {
+		if key, value, ok := strings.Cut(kv, "="); ok && strings.HasPrefix(key, envPrefix) {
+			env[key] = value
+		}
+	}
+	if *path == "" {
+		*path = env[configEnv]
+	}
+	delete(env, configEnv)
+
+	if *path != "" {
+		if err := cfg.readFile(*path); err != nil {
+			return nil, err
+		}
+	}
+	if err := applyEnv(settings, env); err != nil {
+		return nil, err
+	}
+	for _, s := range settings {
+		if raw, ok := flags[s.flag]; ok {
+			if err := s.value.Set(raw); err != nil {
+				return nil, fmt.Errorf("flag -%s: %w", s.flag, err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// readFile decodes the YAML file over the configuration.
+func (c *Config) readFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open configuration file: %w", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("decode configuration file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv sets the settings from their environment variables. Unknown
+// variables are logged and ignored, as the environment holds variables of the
+// prefix set by others, such as the NEWS_PORT of a Kubernetes service named
+// news.
+func applyEnv(settings []*setting, env map[string]string) error {
+	byEnv := make(map[string]*setting, len(settings))
+	for _, s := range settings {
+		byEnv[s.env()] = s
+	}
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(env)) {
+		raw := env[key]
+		s, ok := byEnv[key]
+		if !ok {
+			slog.Warn("ignoring unknown environment variable", "name", key)
+			continue
+		}
+		if err := s.value.Set(raw); err != nil {
+			errs = append(errs, fmt.Errorf("environment variable %s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// value of a setting, bound to its field.
+type value[T any] struct {
+	p      *T
+	parse  func(string) (T, error)
+	format func(T) string
+	isBool bool
+}
+
+func (v *value[T]) String() string {
+	if v == nil || v.p == nil {
+		return ""
+	}
+	return v.format(*v.p)
+}
+
+func (v *value[T]) Set(raw string) error {
+	parsed, err := v.parse(raw)
+	if err != nil {
+		return err
+	}
+	*v.p = parsed
+	return nil
+}
+
+// IsBoolFlag lets the boolean flags be set without a value.
+func (v *value[T]) IsBoolFlag() bool {
+	return v.isBool
+}
+
+func stringVar(p *string) *value[string] {
+	return &value[string]{
+		p:      p,
+		parse:  func(raw string) (string, error) { return raw, nil },
+		format: func(s string) string { return s },
+	}
+}
+
+func intVar(p *int) *value[int] {
+	return &value[int]{p: p, parse: strconv.Atoi, format: strconv.Itoa}
+}
+
+func boolVar(p *bool) *value[bool] {
+	return &value[bool]{p: p, parse: strconv.ParseBool, format: strconv.FormatBool, isBool: true}
+}
+
+func durationVar(p *time.Duration) *value[time.Duration] {
+	return &value[time.Duration]{p: p, parse: time.ParseDuration, format: time.Duration.String}
+}
+
+// listVar of comma separated values.
+func listVar(p *[]string) *value[[]string] {
+	return &value[[]string]{
+		p: p,
+		parse: func(raw string) ([]string, error) {
+			var list []string
+			for item := range strings.SplitSeq(raw, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					list = append(list, item)
+				}
+			}
+			return list, nil
+		},
+		format: func(list []string) string { return strings.Join(list, ",") },
+	}
+}
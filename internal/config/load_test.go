@@ -0,0 +1,174 @@
+package config_test
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codeandlearn1991/news-grpc/internal/config"
+)
+
+const testFile = `
+grpc:
+  addr: ":1"
+log:
+  level: debug
+auth:
+  jwks_file: file.json
+`
+
+// loaded holds the settings of the configuration checked by the tests.
+type loaded struct {
+	addr     string
+	level    string
+	jwksFile string
+	auth     bool
+}
+
+func TestLoad(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// file content of the configuration file, named by NEWS_CONFIG
+		// unless configFlag names it by the -config flag.
+		file       string
+		configFlag bool
+		env        []string
+		args       []string
+		want       loaded
+		wantErr    bool
+	}{
+		{
+			name: "defaults",
+			args: []string{"-auth=false"},
+			want: loaded{addr: ":50051", level: "info"},
+		},
+		{
+			name: "file over defaults",
+			file: testFile,
+			want: loaded{addr: ":1", level: "debug", jwksFile: "file.json", auth: true},
+		},
+		{
+			name: "env over file",
+			file: testFile,
+			env:  []string{"NEWS_GRPC_ADDR=:2", "NEWS_AUTH_JWKS_FILE=env.json"},
+			want: loaded{addr: ":2", level: "debug", jwksFile: "env.json", auth: true},
+		},
+		{
+			name: "flags over env and file",
+			file: testFile,
+			env:  []string{"NEWS_GRPC_ADDR=:2", "NEWS_LOG_LEVEL=warn"},
+			args: []string{"-grpc-addr", ":3"},
+			want: loaded{addr: ":3", level: "warn", jwksFile: "file.json", auth: true},
+		},
+		{
+			name: "boolean flag over env",
+			file: testFile,
+			env:  []string{"NEWS_INTERCEPTORS_AUTH=false"},
+			args: []string{"-auth"},
+			want: loaded{addr: ":1", level: "debug", jwksFile: "file.json", auth: true},
+		},
+		{
+			name: "env disabling a default",
+			env:  []string{"NEWS_INTERCEPTORS_AUTH=false"},
+			want: loaded{addr: ":50051", level: "info"},
+		},
+		{
+			name:       "config flag over env",
+			file:       testFile,
+			configFlag: true,
+			env:        []string{"NEWS_CONFIG=missing.yaml"},
+			want:       loaded{addr: ":1", level: "debug", jwksFile: "file.json", auth: true},
+		},
+		{
+			name:    "auth without keys",
+			wantErr: true,
+		},
+		{
+			name: "unknown environment variables",
+			// As set by Kubernetes for a service named news.
+			env:  []string{"NEWS_PORT=tcp://10.0.0.1:80", "NEWS_SERVICE_HOST=10.0.0.1", "NEWS_GRPC_ADDR=:2"},
+			args: []string{"-auth=false"},
+			want: loaded{addr: ":2", level: "info"},
+		},
+		{
+			name:    "invalid environment variable",
+			env:     []string{"NEWS_GRPC_MAX_RECV_MSG_SIZE=big"},
+			args:    []string{"-auth=false"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown file key",
+			file:    "grpc:\n  unknown: 1\n",
+			args:    []string{"-auth=false"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"-auth=false", "-unknown"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid flag over a valid env",
+			env:     []string{"NEWS_LOG_LEVEL=warn"},
+			args:    []string{"-auth=false", "-log-level", "loud"},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			env, args := tc.env, tc.args
+			if tc.file != "" {
+				path := filepath.Join(t.TempDir(), "config.yaml")
+				if err := os.WriteFile(path, []byte(tc.file), 0o600); err != nil {
+					t.Fatal(err)
+				}
+				if tc.configFlag {
+					args = append([]string{"-config", path}, args...)
+				} else {
+					env = append([]string{"NEWS_CONFIG=" + path}, env...)
+				}
+			}
+
+			cfg, err := config.Load("server", args, env)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Load() error = %v, want error %t", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got := loaded{
+				addr:     cfg.GRPC.Addr,
+				level:    cfg.Log.Level,
+				jwksFile: cfg.Auth.JWKSFile,
+				auth:     cfg.Interceptors.Auth,
+			}
+			if got != tc.want {
+				t.Errorf("Load() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadWarnsOfUnknownEnv(t *testing.T) {
+	var logged bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logged, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})))
+
+	if _, err := config.Load("server", []string{"-auth=false"}, []string{"NEWS_SERVICE_HOST=10.0.0.1", "NEWS_PORT=80"}); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := "level=WARN msg=\"ignoring unknown environment variable\" name=NEWS_PORT\n" +
+		"level=WARN msg=\"ignoring unknown environment variable\" name=NEWS_SERVICE_HOST\n"
+	if got := logged.String(); got != want {
+		t.Errorf("Load() logged %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,305 @@
+// Package connect serves a NewsService implementation over the Connect,
+// gRPC-Web and gRPC protocols, so that browsers can call it without a proxy.
+// The calls go through the same gRPC interceptors as the gRPC server.
+package connect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	connectrpc "connectrpc.com/connect"
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Option to configure the handler.
+type Option func(*Handler)
+
+// WithUnaryInterceptors runs the interceptors around the unary calls, the
+// first one being the outermost.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) Option {
+	return func(h *Handler) {
+		h.unaryInterceptors = append(h.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors runs the interceptors around the streaming calls, the
+// first one being the outermost.
+func WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) Option {
+	return func(h *Handler) {
+		h.streamInterceptors = append(h.streamInterceptors, interceptors...)
+	}
+}
+
+// Handler implements newsv1connect.NewsServiceHandler on top of the gRPC
+// implementation of the service.
+type Handler struct {
+	srv                newsv1.NewsServiceServer
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+}
+
+// NewHandler returns an initialized instance of Handler.
+func NewHandler(srv newsv1.NewsServiceServer, opts ...Option) *Handler {
+	h := &Handler{srv: srv}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// unary calls the gRPC method through the unary interceptors.
+func unary[Req, Res any](
+	ctx context.Context, h *Handler, req *connectrpc.Request[Req], call func(context.Context, *Req) (*Res, error),
+) (*connectrpc.Response[Res], error) {
+	info := &grpc.UnaryServerInfo{Server: h.srv, FullMethod: req.Spec().Procedure}
+	handler := func(ctx context.Context, in any) (any, error) {
+		msg, ok := in.(*Req)
+		if !ok {
+			return nil, fmt.Errorf("unexpected request %T", in)
+		}
+		return call(ctx, msg)
+	}
+	for i := len(h.unaryInterceptors) - 1; i >= 0; i-- {
+		interceptor, next := h.unaryInterceptors[i], handler
+		handler = func(ctx context.Context, in any) (any, error) {
+			return interceptor(ctx, in, info, next)
+		}
+	}
+
+	// The handlers set the headers and trailers of the response through the
+	// transport stream, as they do on the gRPC server.
+	ts := &transportStream{method: info.FullMethod, header: metadata.MD{}, trailer: metadata.MD{}}
+	ctx = grpc.NewContextWithServerTransportStream(incomingContext(ctx, req.Header()), ts)
+	out, err := handler(ctx, req.Msg)
+	if err != nil {
+		connectErr := toConnectError(err)
+		appendMetadata(connectErr.Meta(), ts.header)
+		appendMetadata(connectErr.Meta(), ts.trailer)
+		return nil, connectErr
+	}
+	res, ok := out.(*Res)
+	if !ok {
+		return nil, connectrpc.NewError(connectrpc.CodeInternal, fmt.Errorf("unexpected response %T", out))
+	}
+	resp := connectrpc.NewResponse(res)
+	appendMetadata(resp.Header(), ts.header)
+	appendMetadata(resp.Trailer(), ts.trailer)
+	return resp, nil
+}
+
+// transportStream collects the headers and trailers of a unary call.
+type transportStream struct {
+	method  string
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+func (s *transportStream) Method() string {
+	return s.method
+}
+
+func (s *transportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// SendHeader only sets the headers, they are sent along with the response.
+func (s *transportStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *transportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+// serverStreaming calls the gRPC method with the request through the stream
+// interceptors, which receive the request as the only message of the stream.
+func serverStreaming[Req, Res any](
+	ctx context.Context, h *Handler, req *connectrpc.Request[Req], stream *connectrpc.ServerStream[Res],
+	call func(*Req, grpc.ServerStreamingServer[Res]) error,
+) error {
+	received := false
+	recv := func(m any) error {
+		if received {
+			return io.EOF
+		}
+		received = true
+		dst, ok := m.(proto.Message)
+		if !ok {
+			return fmt.Errorf("unexpected message %T", m)
+		}
+		src, ok := any(req.Msg).(proto.Message)
+		if !ok {
+			return fmt.Errorf("unexpected request %T", req.Msg)
+		}
+		proto.Merge(dst, src)
+		return nil
+	}
+	conn := stream.Conn()
+	return h.serveStream(ctx, conn, recv, conn.Send, func(ss grpc.ServerStream) error {
+		in := new(Req)
+		if err := ss.RecvMsg(in); err != nil {
+			return err //nolint:wrapcheck // The status set by the interceptors is kept.
+		}
+		return call(in, &grpc.GenericServerStream[Req, Res]{ServerStream: ss})
+	})
+}
+
+// clientStreaming calls the gRPC method through the stream interceptors and
+// responds with the message it closes the stream with.
+func clientStreaming[Req, Res any](
+	ctx context.Context, h *Handler, stream *connectrpc.ClientStream[Req],
+	call func(grpc.ClientStreamingServer[Req, Res]) error,
+) (*connectrpc.Response[Res], error) {
+	var out any
+	send := func(m any) error {
+		out = m
+		return nil
+	}
+	conn := stream.Conn()
+	err := h.serveStream(ctx, conn, conn.Receive, send, func(ss grpc.ServerStream) error {
+		return call(&grpc.GenericServerStream[Req, Res]{ServerStream: ss})
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, ok := out.(*Res)
+	if !ok {
+		return nil, connectrpc.NewError(connectrpc.CodeInternal, fmt.Errorf("unexpected response %T", out))
+	}
+	return connectrpc.NewResponse(res), nil
+}
+
+// bidiStreaming calls the gRPC method through the stream interceptors.
+func bidiStreaming[Req, Res any](
+	ctx context.Context, h *Handler, stream *connectrpc.BidiStream[Req, Res],
+	call func(grpc.BidiStreamingServer[Req, Res]) error,
+) error {
+	conn := stream.Conn()
+	return h.serveStream(ctx, conn, conn.Receive, conn.Send, func(ss grpc.ServerStream) error {
+		return call(&grpc.GenericServerStream[Req, Res]{ServerStream: ss})
+	})
+}
+
+// serveStream runs the handler through the stream interceptors with a gRPC
+// stream receiving and sending the messages with the functions.
+func (h *Handler) serveStream(
+	ctx context.Context, conn connectrpc.StreamingHandlerConn,
+	recv, send func(m any) error, handler func(grpc.ServerStream) error,
+) error {
+	spec := conn.Spec()
+	info := &grpc.StreamServerInfo{
+		FullMethod:     spec.Procedure,
+		IsClientStream: spec.StreamType&connectrpc.StreamTypeClient != 0,
+		IsServerStream: spec.StreamType&connectrpc.StreamTypeServer != 0,
+	}
+	streamHandler := func(_ any, ss grpc.ServerStream) error {
+		return handler(ss)
+	}
+	for i := len(h.streamInterceptors) - 1; i >= 0; i-- {
+		interceptor, next := h.streamInterceptors[i], streamHandler
+		streamHandler = func(srv any, ss grpc.ServerStream) error {
+			return interceptor(srv, ss, info, next)
+		}
+	}
+
+	ss := &serverStream{
+		ctx:  incomingContext(ctx, conn.RequestHeader()),
+		conn: conn,
+		recv: recv,
+		send: send,
+	}
+	return toError(streamHandler(h.srv, ss))
+}
+
+// serverStream is the gRPC stream of a Connect streaming call.
+type serverStream struct {
+	ctx  context.Context //nolint:containedctx // The context of the call, held by the gRPC streams.
+	conn connectrpc.StreamingHandlerConn
+	recv func(m any) error
+	send func(m any) error
+}
+
+func (s *serverStream) SetHeader(md metadata.MD) error {
+	appendMetadata(s.conn.ResponseHeader(), md)
+	return nil
+}
+
+// SendHeader only sets the headers, they are sent along with the first
+// message.
+func (s *serverStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *serverStream) SetTrailer(md metadata.MD) {
+	appendMetadata(s.conn.ResponseTrailer(), md)
+}
+
+//nolint:ireturn // Required by grpc.ServerStream.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	return s.send(m)
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	return s.recv(m)
+}
+
+// incomingContext returns the context with the request headers as the
+// incoming gRPC metadata.
+//
+//nolint:ireturn // The context is derived.
+func incomingContext(ctx context.Context, header http.Header) context.Context {
+	md := make(metadata.MD, len(header))
+	for key, values := range header {
+		md.Append(key, values...)
+	}
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+func appendMetadata(header http.Header, md metadata.MD) {
+	for key, values := range md {
+		for _, value := range values {
+			header.Add(key, value)
+		}
+	}
+}
+
+// toError converts the gRPC status of the error, along with its details, to a
+// Connect error.
+func toError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return toConnectError(err)
+}
+
+// toConnectError converts the gRPC status of the non-nil error to a Connect
+// error.
+func toConnectError(err error) *connectrpc.Error {
+	var connectErr *connectrpc.Error
+	if errors.As(err, &connectErr) {
+		return connectErr
+	}
+	st := status.Convert(err)
+	connectErr = connectrpc.NewError(connectrpc.Code(st.Code()), errors.New(st.Message()))
+	for _, detail := range st.Proto().GetDetails() {
+		errDetail, detailErr := connectrpc.NewErrorDetail(detail)
+		if detailErr != nil {
+			continue
+		}
+		connectErr.AddDetail(errDetail)
+	}
+	return connectErr
+}
@@ -0,0 +1,256 @@
+package connect_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	connectrpc "connectrpc.com/connect"
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/api/news/v1/newsv1connect"
+	"github.com/codeandlearn1991/news-grpc/internal/connect"
+	ingrpc "github.com/codeandlearn1991/news-grpc/internal/grpc"
+	"github.com/codeandlearn1991/news-grpc/internal/logging"
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// newServer returns the URL of a server of the handler of a news server of
+// the editors, serving HTTP/1.1 and HTTP/2 without TLS (h2c). The calls are
+// logged so that their request ID is sent back.
+func newServer(t *testing.T) string {
+	t.Helper()
+
+	logger := slog.New(slog.DiscardHandler)
+	path, handler := newsv1connect.NewNewsServiceHandler(connect.NewHandler(
+		ingrpc.NewServer(memstore.New(), ingrpc.WithPrivileged(func(context.Context) bool { return true })),
+		connect.WithUnaryInterceptors(logging.UnaryServerInterceptor(logger)),
+		connect.WithStreamInterceptors(logging.StreamServerInterceptor(logger)),
+	))
+	mux := http.NewServeMux()
+	mux.Handle(path, connect.PeerHandler(handler))
+
+	srv := httptest.NewUnstartedServer(mux)
+	srv.Config.Protocols = new(http.Protocols)
+	srv.Config.Protocols.SetHTTP1(true)
+	srv.Config.Protocols.SetUnencryptedHTTP2(true)
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// h2cClient returns an HTTP client speaking HTTP/2 without TLS only.
+func h2cClient() *http.Client {
+	transport := &http.Transport{Protocols: new(http.Protocols)}
+	transport.Protocols.SetUnencryptedHTTP2(true)
+	return &http.Client{Transport: transport}
+}
+
+func createRequest() *newsv1.CreateRequest {
+	return &newsv1.CreateRequest{
+		Id:      uuid.NewString(),
+		Author:  "author",
+		Title:   "a valid title",
+		Summary: "a summary long enough",
+		Content: "some content",
+		Source:  "https://example.com",
+		Tags:    []string{"tag"},
+	}
+}
+
+func TestHandlerProtocols(t *testing.T) {
+	url := newServer(t)
+	for _, tc := range []struct {
+		name       string
+		httpClient *http.Client
+		opts       []connectrpc.ClientOption
+		// bidi streams, which require HTTP/2.
+		bidi bool
+	}{
+		{name: "connect", httpClient: h2cClient(), bidi: true},
+		{name: "connect over HTTP/1.1", httpClient: http.DefaultClient},
+		{name: "grpc", httpClient: h2cClient(), opts: []connectrpc.ClientOption{connectrpc.WithGRPC()}, bidi: true},
+		{name: "grpc-web", httpClient: h2cClient(), opts: []connectrpc.ClientOption{connectrpc.WithGRPCWeb()}, bidi: true},
+		{name: "grpc-web over HTTP/1.1", httpClient: http.DefaultClient, opts: []connectrpc.ClientOption{connectrpc.WithGRPCWeb()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newsv1connect.NewNewsServiceClient(tc.httpClient, url, tc.opts...)
+			t.Run("unary", func(t *testing.T) { testUnary(t, client) })
+			t.Run("errors", func(t *testing.T) { testErrors(t, client) })
+			t.Run("client stream", func(t *testing.T) { testClientStream(t, client) })
+			if tc.bidi {
+				t.Run("bidi stream", func(t *testing.T) { testBidiStream(t, client) })
+			}
+		})
+	}
+}
+
+func testUnary(t *testing.T, client newsv1connect.NewsServiceClient) {
+	t.Helper()
+
+	ctx := t.Context()
+	req := createRequest()
+	created, err := client.Create(ctx, connectrpc.NewRequest(req))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Header().Get(logging.RequestIDKey) == "" {
+		t.Errorf("Create() has no %s header", logging.RequestIDKey)
+	}
+
+	getReq := connectrpc.NewRequest(&newsv1.GetRequest{Id: req.GetId()})
+	getReq.Header().Set(logging.RequestIDKey, "request-1")
+	got, err := client.Get(ctx, getReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Msg.GetTitle() != req.GetTitle() || got.Msg.GetVersion() != created.Msg.GetVersion() {
+		t.Errorf("Get() = %q at version %d, want %q at version %d",
+			got.Msg.GetTitle(), got.Msg.GetVersion(), req.GetTitle(), created.Msg.GetVersion())
+	}
+	if id := got.Header().Get(logging.RequestIDKey); id != "request-1" {
+		t.Errorf("Get() %s header = %q, want %q", logging.RequestIDKey, id, "request-1")
+	}
+}
+
+func testErrors(t *testing.T, client newsv1connect.NewsServiceClient) {
+	t.Helper()
+
+	ctx := t.Context()
+	_, err := client.Get(ctx, connectrpc.NewRequest(&newsv1.GetRequest{Id: uuid.NewString()}))
+	if got := connectrpc.CodeOf(err); got != connectrpc.CodeNotFound {
+		t.Errorf("Get() of an unknown news code = %s, want %s", got, connectrpc.CodeNotFound)
+	}
+
+	_, err = client.Get(ctx, connectrpc.NewRequest(&newsv1.GetRequest{Id: "not a uuid"}))
+	var connectErr *connectrpc.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connectrpc.CodeInvalidArgument {
+		t.Fatalf("Get() of an invalid id error = %v, want %s", err, connectrpc.CodeInvalidArgument)
+	}
+	var fields []string
+	for _, detail := range connectErr.Details() {
+		value, valueErr := detail.Value()
+		if valueErr != nil {
+			t.Fatal(valueErr)
+		}
+		if badRequest, ok := value.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				fields = append(fields, violation.GetField())
+			}
+		}
+	}
+	if want := []string{"id"}; !slices.Equal(fields, want) {
+		t.Errorf("Get() of an invalid id violated fields = %q, want %q", fields, want)
+	}
+}
+
+func testClientStream(t *testing.T, client newsv1connect.NewsServiceClient) {
+	t.Helper()
+
+	ctx := t.Context()
+	req := createRequest()
+	if _, err := client.Create(ctx, connectrpc.NewRequest(req)); err != nil {
+		t.Fatal(err)
+	}
+
+	stream := client.UpdateNews(ctx)
+	for _, id := range []string{req.GetId(), uuid.NewString()} {
+		if err := stream.Send(&newsv1.UpdateNewsRequest{
+			News:       &newsv1.News{Id: id, Title: "an updated title"},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	res, err := stream.CloseAndReceive()
+	if err != nil {
+		t.Fatal(err)
+	}
+	outcomes := make([]newsv1.UpdateNewsResult_Outcome, 0, len(res.Msg.GetResults()))
+	for _, result := range res.Msg.GetResults() {
+		outcomes = append(outcomes, result.GetOutcome())
+	}
+	want := []newsv1.UpdateNewsResult_Outcome{
+		newsv1.UpdateNewsResult_OUTCOME_UPDATED,
+		newsv1.UpdateNewsResult_OUTCOME_NOT_FOUND,
+	}
+	if !slices.Equal(outcomes, want) {
+		t.Errorf("UpdateNews() outcomes = %v, want %v", outcomes, want)
+	}
+}
+
+func testBidiStream(t *testing.T, client newsv1connect.NewsServiceClient) {
+	t.Helper()
+
+	ctx := t.Context()
+	req := createRequest()
+	if _, err := client.Create(ctx, connectrpc.NewRequest(req)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every id is acknowledged before the next one is sent.
+	stream := client.DeletedNews(ctx)
+	for _, want := range []newsv1.DeletedNewsResponse_Outcome{
+		newsv1.DeletedNewsResponse_OUTCOME_DELETED,
+		newsv1.DeletedNewsResponse_OUTCOME_ALREADY_DELETED,
+	} {
+		if err := stream.Send(&newsv1.NewsID{Id: req.GetId()}); err != nil {
+			t.Fatal(err)
+		}
+		res, err := stream.Receive()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.GetId() != req.GetId() || res.GetOutcome() != want {
+			t.Errorf("DeletedNews() = %s of %q, want %s of %q", res.GetOutcome(), res.GetId(), want, req.GetId())
+		}
+	}
+	if err := stream.CloseRequest(); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.CloseResponse(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHandlerGRPCClient(t *testing.T) {
+	ctx := t.Context()
+	conn, err := grpc.NewClient(newServer(t)[len("http://"):], grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := newsv1.NewNewsServiceClient(conn)
+
+	req := createRequest()
+	if _, err = client.Create(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	var header metadata.MD
+	got, err := client.Get(ctx, &newsv1.GetRequest{Id: req.GetId()}, grpc.Header(&header))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetTitle() != req.GetTitle() {
+		t.Errorf("Get() title = %q, want %q", got.GetTitle(), req.GetTitle())
+	}
+	if len(header.Get(logging.RequestIDKey)) != 1 {
+		t.Errorf("Get() %s header = %q, want one", logging.RequestIDKey, header.Get(logging.RequestIDKey))
+	}
+
+	_, err = client.Get(ctx, &newsv1.GetRequest{Id: uuid.NewString()})
+	if got := status.Code(err); got != codes.NotFound {
+		t.Errorf("Get() of an unknown news code = %s, want %s", got, codes.NotFound)
+	}
+}
@@ -0,0 +1,44 @@
+package connect
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// PeerHandler sets the client of the requests as the gRPC peer of their
+// context, along with its TLS connection state, as the gRPC server does. The
+// interceptors then see the same peer on both servers.
+func PeerHandler(next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		p := &peer.Peer{Addr: remoteAddr(r.RemoteAddr)}
+		if r.TLS != nil {
+			p.AuthInfo = credentials.TLSInfo{
+				State:          *r.TLS,
+				CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(peer.NewContext(r.Context(), p)))
+	}
+}
+
+// remoteAddr parses the address of the client, kept as is when it is not a
+// TCP one.
+//
+//nolint:ireturn // The address is either TCP or opaque.
+func remoteAddr(addr string) net.Addr {
+	if addrPort, err := netip.ParseAddrPort(addr); err == nil {
+		return net.TCPAddrFromAddrPort(addrPort)
+	}
+	return opaqueAddr(addr)
+}
+
+// opaqueAddr is an address of an unknown network.
+type opaqueAddr string
+
+func (a opaqueAddr) Network() string { return "" }
+
+func (a opaqueAddr) String() string { return string(a) }
@@ -0,0 +1,144 @@
+package connect
+
+import (
+	"context"
+
+	connectrpc "connectrpc.com/connect"
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/api/news/v1/newsv1connect"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var _ newsv1connect.NewsServiceHandler = (*Handler)(nil)
+
+// Create serves the Create RPC with the gRPC implementation.
+func (h *Handler) Create(ctx context.Context, req *connectrpc.Request[newsv1.CreateRequest]) (*connectrpc.Response[newsv1.CreateResponse], error) {
+	return unary(ctx, h, req, h.srv.Create)
+}
+
+// Get serves the Get RPC with the gRPC implementation.
+func (h *Handler) Get(ctx context.Context, req *connectrpc.Request[newsv1.GetRequest]) (*connectrpc.Response[newsv1.GetResponse], error) {
+	return unary(ctx, h, req, h.srv.Get)
+}
+
+// BatchGetNews serves the BatchGetNews RPC with the gRPC implementation.
+func (h *Handler) BatchGetNews(
+	ctx context.Context, req *connectrpc.Request[newsv1.BatchGetNewsRequest],
+) (*connectrpc.Response[newsv1.BatchGetNewsResponse], error) {
+	return unary(ctx, h, req, h.srv.BatchGetNews)
+}
+
+// GetAll serves the GetAll RPC with the gRPC implementation.
+func (h *Handler) GetAll(ctx context.Context, req *connectrpc.Request[emptypb.Empty], stream *connectrpc.ServerStream[newsv1.GetAllResponse]) error {
+	return serverStreaming(ctx, h, req, stream, h.srv.GetAll)
+}
+
+// WatchNews serves the WatchNews RPC with the gRPC implementation.
+func (h *Handler) WatchNews(
+	ctx context.Context, req *connectrpc.Request[newsv1.WatchNewsRequest], stream *connectrpc.ServerStream[newsv1.WatchNewsResponse],
+) error {
+	return serverStreaming(ctx, h, req, stream, h.srv.WatchNews)
+}
+
+// ListChanges serves the ListChanges RPC with the gRPC implementation.
+func (h *Handler) ListChanges(
+	ctx context.Context, req *connectrpc.Request[newsv1.ListChangesRequest],
+) (*connectrpc.Response[newsv1.ListChangesResponse], error) {
+	return unary(ctx, h, req, h.srv.ListChanges)
+}
+
+// ListNews serves the ListNews RPC with the gRPC implementation.
+func (h *Handler) ListNews(ctx context.Context, req *connectrpc.Request[newsv1.ListNewsRequest]) (*connectrpc.Response[newsv1.ListNewsResponse], error) {
+	return unary(ctx, h, req, h.srv.ListNews)
+}
+
+// SearchNews serves the SearchNews RPC with the gRPC implementation.
+func (h *Handler) SearchNews(ctx context.Context, req *connectrpc.Request[newsv1.SearchNewsRequest]) (*connectrpc.Response[newsv1.SearchNewsResponse], error) {
+	return unary(ctx, h, req, h.srv.SearchNews)
+}
+
+// ListRevisions serves the ListRevisions RPC with the gRPC implementation.
+func (h *Handler) ListRevisions(
+	ctx context.Context, req *connectrpc.Request[newsv1.ListRevisionsRequest],
+) (*connectrpc.Response[newsv1.ListRevisionsResponse], error) {
+	return unary(ctx, h, req, h.srv.ListRevisions)
+}
+
+// GetRevision serves the GetRevision RPC with the gRPC implementation.
+func (h *Handler) GetRevision(ctx context.Context, req *connectrpc.Request[newsv1.GetRevisionRequest]) (*connectrpc.Response[newsv1.Revision], error) {
+	return unary(ctx, h, req, h.srv.GetRevision)
+}
+
+// DiffRevisions serves the DiffRevisions RPC with the gRPC implementation.
+func (h *Handler) DiffRevisions(
+	ctx context.Context, req *connectrpc.Request[newsv1.DiffRevisionsRequest],
+) (*connectrpc.Response[newsv1.DiffRevisionsResponse], error) {
+	return unary(ctx, h, req, h.srv.DiffRevisions)
+}
+
+// RollbackNews serves the RollbackNews RPC with the gRPC implementation.
+func (h *Handler) RollbackNews(ctx context.Context, req *connectrpc.Request[newsv1.RollbackNewsRequest]) (*connectrpc.Response[newsv1.News], error) {
+	return unary(ctx, h, req, h.srv.RollbackNews)
+}
+
+// Update serves the Update RPC with the gRPC implementation.
+func (h *Handler) Update(ctx context.Context, req *connectrpc.Request[newsv1.UpdateNewsRequest]) (*connectrpc.Response[newsv1.News], error) {
+	return unary(ctx, h, req, h.srv.Update)
+}
+
+// UpdateNews serves the UpdateNews RPC with the gRPC implementation.
+func (h *Handler) UpdateNews(
+	ctx context.Context, stream *connectrpc.ClientStream[newsv1.UpdateNewsRequest],
+) (*connectrpc.Response[newsv1.UpdateNewsResponse], error) {
+	return clientStreaming(ctx, h, stream, h.srv.UpdateNews)
+}
+
+// DeletedNews serves the DeletedNews RPC with the gRPC implementation.
+func (h *Handler) DeletedNews(ctx context.Context, stream *connectrpc.BidiStream[newsv1.NewsID, newsv1.DeletedNewsResponse]) error {
+	return bidiStreaming(ctx, h, stream, h.srv.DeletedNews)
+}
+
+// SubmitNews serves the SubmitNews RPC with the gRPC implementation.
+func (h *Handler) SubmitNews(ctx context.Context, req *connectrpc.Request[newsv1.TransitionNewsRequest]) (*connectrpc.Response[newsv1.News], error) {
+	return unary(ctx, h, req, h.srv.SubmitNews)
+}
+
+// ApproveNews serves the ApproveNews RPC with the gRPC implementation.
+func (h *Handler) ApproveNews(ctx context.Context, req *connectrpc.Request[newsv1.TransitionNewsRequest]) (*connectrpc.Response[newsv1.News], error) {
+	return unary(ctx, h, req, h.srv.ApproveNews)
+}
+
+// RejectNews serves the RejectNews RPC with the gRPC implementation.
+func (h *Handler) RejectNews(ctx context.Context, req *connectrpc.Request[newsv1.TransitionNewsRequest]) (*connectrpc.Response[newsv1.News], error) {
+	return unary(ctx, h, req, h.srv.RejectNews)
+}
+
+// PublishNews serves the PublishNews RPC with the gRPC implementation.
+func (h *Handler) PublishNews(ctx context.Context, req *connectrpc.Request[newsv1.TransitionNewsRequest]) (*connectrpc.Response[newsv1.News], error) {
+	return unary(ctx, h, req, h.srv.PublishNews)
+}
+
+// ArchiveNews serves the ArchiveNews RPC with the gRPC implementation.
+func (h *Handler) ArchiveNews(ctx context.Context, req *connectrpc.Request[newsv1.TransitionNewsRequest]) (*connectrpc.Response[newsv1.News], error) {
+	return unary(ctx, h, req, h.srv.ArchiveNews)
+}
+
+// ListTrash serves the ListTrash RPC with the gRPC implementation.
+func (h *Handler) ListTrash(ctx context.Context, req *connectrpc.Request[newsv1.ListTrashRequest]) (*connectrpc.Response[newsv1.ListTrashResponse], error) {
+	return unary(ctx, h, req, h.srv.ListTrash)
+}
+
+// RestoreNews serves the RestoreNews RPC with the gRPC implementation.
+func (h *Handler) RestoreNews(ctx context.Context, req *connectrpc.Request[newsv1.RestoreNewsRequest]) (*connectrpc.Response[newsv1.News], error) {
+	return unary(ctx, h, req, h.srv.RestoreNews)
+}
+
+// PurgeNews serves the PurgeNews RPC with the gRPC implementation.
+func (h *Handler) PurgeNews(ctx context.Context, req *connectrpc.Request[newsv1.PurgeNewsRequest]) (*connectrpc.Response[newsv1.PurgeNewsResponse], error) {
+	return unary(ctx, h, req, h.srv.PurgeNews)
+}
+
+// EmptyTrash serves the EmptyTrash RPC with the gRPC implementation.
+func (h *Handler) EmptyTrash(ctx context.Context, req *connectrpc.Request[newsv1.EmptyTrashRequest]) (*connectrpc.Response[newsv1.EmptyTrashResponse], error) {
+	return unary(ctx, h, req, h.srv.EmptyTrash)
+}
@@ -0,0 +1,212 @@
+package diskstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/google/uuid"
+)
+
+// record is the on-disk representation of a news.
+type record struct {
+	ID        uuid.UUID `json:"id"`
+	Author    string    `json:"author"`
+	Title     string    `json:"title"`
+	Summary   string    `json:"summary"`
+	Content   string    `json:"content"`
+	Source    string    `json:"source"`
+	Tags      []string  `json:"tags"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	DeletedAt time.Time `json:"deleted_at"`
+	Version   int64     `json:"version"`
+	Seq       int64     `json:"seq,omitempty"`
+	State     string    `json:"state,omitempty"`
+	// PublishAt and EmbargoUntil are only written when set.
+	PublishAt    *time.Time `json:"publish_at,omitempty"`
+	EmbargoUntil *time.Time `json:"embargo_until,omitempty"`
+	Editor       string     `json:"editor,omitempty"`
+}
+
+// stateNames of the states as written to disk.
+var stateNames = map[memstore.State]string{
+	memstore.StateDraft:     "draft",
+	memstore.StateInReview:  "in_review",
+	memstore.StatePublished: "published",
+	memstore.StateArchived:  "archived",
+	memstore.StateScheduled: "scheduled",
+}
+
+func toRecord(news *memstore.News) *record {
+	rec := &record{
+		ID:        news.ID,
+		Author:    news.Author,
+		Title:     news.Title,
+		Summary:   news.Summary,
+		Content:   news.Content,
+		Tags:      news.Tags,
+		CreatedAt: news.CreatedAt,
+		UpdatedAt: news.UpdatedAt,
+		DeletedAt: news.DeletedAt,
+		Version:   news.Version,
+		Seq:       news.Seq,
+		State:     stateNames[news.State],
+		Editor:    news.Editor,
+	}
+	if news.Source != nil {
+		rec.Source = news.Source.String()
+	}
+	if !news.PublishAt.IsZero() {
+		rec.PublishAt = &news.PublishAt
+	}
+	if !news.EmbargoUntil.IsZero() {
+		rec.EmbargoUntil = &news.EmbargoUntil
+	}
+	return rec
+}
+
+func (r *record) toNews() (*memstore.News, error) {
+	source, err := url.Parse(r.Source)
+	if err != nil {
+		return nil, fmt.Errorf("news %s source: %w", r.ID, err)
+	}
+	// News written before the workflow existed were visible to the readers.
+	state := memstore.StatePublished
+	if r.State != "" {
+		if state, err = parseState(r.State); err != nil {
+			return nil, fmt.Errorf("news %s: %w", r.ID, err)
+		}
+	}
+	news := &memstore.News{
+		ID:        r.ID,
+		Author:    r.Author,
+		Title:     r.Title,
+		Summary:   r.Summary,
+		Content:   r.Content,
+		Source:    source,
+		Tags:      r.Tags,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+		DeletedAt: r.DeletedAt,
+		Version:   r.Version,
+		Seq:       r.Seq,
+		State:     state,
+		Editor:    r.Editor,
+	}
+	if r.PublishAt != nil {
+		news.PublishAt = *r.PublishAt
+	}
+	if r.EmbargoUntil != nil {
+		news.EmbargoUntil = *r.EmbargoUntil
+	}
+	return news, nil
+}
+
+func parseState(name string) (memstore.State, error) {
+	for state, stateName := range stateNames {
+		if stateName == name {
+			return state, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown state %q", name)
+}
+
+// entry is a news along with revisions of it, as written to the write-ahead
+// log and to the snapshot. The record is embedded so that entries written
+// before revisions existed still decode. A purged entry only holds the id and
+// the sequence of the purged news.
+type entry struct {
+	record
+	Revisions []revisionRecord `json:"revisions,omitempty"`
+	Purged    bool             `json:"purged,omitempty"`
+}
+
+func purgedEntry(id uuid.UUID, seq int64) *entry {
+	return &entry{record: record{ID: id, Seq: seq}, Purged: true}
+}
+
+// encodeEntries encodes a single entry as a JSON object, and several entries
+// as a JSON array so that they are replayed together.
+func encodeEntries(entries []*entry) ([]byte, error) {
+	var (
+		payload []byte
+		err     error
+	)
+	if len(entries) == 1 {
+		payload, err = json.Marshal(entries[0])
+	} else {
+		payload, err = json.Marshal(entries)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+	return payload, nil
+}
+
+// decodeEntries decodes the entries encoded by encodeEntries.
+func decodeEntries(payload []byte) ([]*entry, error) {
+	var entries []*entry
+	if bytes.HasPrefix(payload, []byte("[")) {
+		if err := json.Unmarshal(payload, &entries); err != nil {
+			return nil, fmt.Errorf("decode: %w", err)
+		}
+		return entries, nil
+	}
+	var e entry
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	return append(entries, &e), nil
+}
+
+// revisionRecord is the on-disk representation of a revision.
+type revisionRecord struct {
+	Number        int64     `json:"number"`
+	Author        string    `json:"author"`
+	CreatedAt     time.Time `json:"created_at"`
+	ChangedFields []string  `json:"changed_fields"`
+	News          *record   `json:"news"`
+}
+
+func toEntry(news *memstore.News, revisions []*memstore.Revision) *entry {
+	e := &entry{record: *toRecord(news)}
+	for _, revision := range revisions {
+		e.Revisions = append(e.Revisions, revisionRecord{
+			Number:        revision.Number,
+			Author:        revision.Author,
+			CreatedAt:     revision.CreatedAt,
+			ChangedFields: revision.ChangedFields,
+			News:          toRecord(revision.News),
+		})
+	}
+	return e
+}
+
+func (e *entry) toNews() (*memstore.News, []*memstore.Revision, error) {
+	news, err := e.record.toNews()
+	if err != nil {
+		return nil, nil, err
+	}
+	revisions := make([]*memstore.Revision, 0, len(e.Revisions))
+	for _, rec := range e.Revisions {
+		if rec.News == nil {
+			return nil, nil, fmt.Errorf("news %s revision %d: missing news", e.ID, rec.Number)
+		}
+		revisionNews, err := rec.News.toNews()
+		if err != nil {
+			return nil, nil, fmt.Errorf("news %s revision %d: %w", e.ID, rec.Number, err)
+		}
+		revisions = append(revisions, &memstore.Revision{
+			Number:        rec.Number,
+			News:          revisionNews,
+			Author:        rec.Author,
+			CreatedAt:     rec.CreatedAt,
+			ChangedFields: rec.ChangedFields,
+		})
+	}
+	return news, revisions, nil
+}
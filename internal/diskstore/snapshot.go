@@ -0,0 +1,88 @@
+package diskstore
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// writeSnapshot atomically replaces the snapshot at path with the entries, one
+// JSON entry per line.
+func writeSnapshot(path string, entries []*entry) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create snapshot: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, os.Remove(tmp.Name()))
+		}
+	}()
+
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	for _, e := range entries {
+		if err = encoder.Encode(e); err != nil {
+			return errors.Join(fmt.Errorf("encode snapshot: %w", err), tmp.Close())
+		}
+	}
+	if err = writer.Flush(); err != nil {
+		return errors.Join(fmt.Errorf("write snapshot: %w", err), tmp.Close())
+	}
+	if err = tmp.Sync(); err != nil {
+		return errors.Join(fmt.Errorf("sync snapshot: %w", err), tmp.Close())
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("close snapshot: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("rename snapshot: %w", err)
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+// readSnapshot calls apply for every entry of the snapshot at path. A missing
+// snapshot is treated as empty.
+func readSnapshot(path string, apply func(e *entry) error) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("open snapshot: %w", err)
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(file))
+	for {
+		var e entry
+		if err = decoder.Decode(&e); errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("decode snapshot: %w", err)
+		}
+		if err = apply(&e); err != nil {
+			return fmt.Errorf("decode snapshot: %w", err)
+		}
+	}
+}
+
+// syncDir flushes the directory entries so that renames survive a crash.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("open data dir: %w", err)
+	}
+	if err = d.Sync(); err != nil {
+		return errors.Join(fmt.Errorf("sync data dir: %w", err), d.Close())
+	}
+	if err = d.Close(); err != nil {
+		return fmt.Errorf("close data dir: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,356 @@
+package diskstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/google/uuid"
+)
+
+const (
+	snapshotFile = "snapshot.jsonl"
+	walFile      = "wal.log"
+
+	defaultSnapshotEvery = 1000
+)
+
+// Config of the disk store.
+type Config struct {
+	// Dir holding the snapshot and the write-ahead log, created when missing.
+	Dir string
+	// SnapshotEvery number of writes after which the write-ahead log is
+	// compacted into a snapshot, defaults to 1000.
+	SnapshotEvery int
+}
+
+// Store keeps the news in memory and makes every write durable by appending
+// it to a write-ahead log before applying it. The log is periodically
+// compacted into a snapshot and both are replayed on open.
+type Store struct {
+	// lock serializes the writes and the compactions of the log.
+	lock          sync.Mutex
+	mem           *memstore.Store
+	wal           *wal
+	dir           string
+	snapshotEvery int
+	writes        int
+	// err is the failure that stopped the store from accepting writes.
+	err error
+}
+
+// Open the store in the configured directory and restore its content. The
+// options configure the in-memory store serving the reads.
+func Open(cfg Config, opts ...memstore.Option) (*Store, error) {
+	if err := os.MkdirAll(cfg.Dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create data dir: %w", err)
+	}
+
+	s := &Store{
+		dir:           cfg.Dir,
+		snapshotEvery: cfg.SnapshotEvery,
+	}
+	s.mem = memstore.New(append(opts, memstore.WithJournal((*journal)(s)))...)
+	if s.snapshotEvery <= 0 {
+		s.snapshotEvery = defaultSnapshotEvery
+	}
+
+	if err := readSnapshot(filepath.Join(cfg.Dir, snapshotFile), s.restore); err != nil {
+		return nil, err
+	}
+
+	w, err := openWAL(filepath.Join(cfg.Dir, walFile))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = w.replay(func(payload []byte) error {
+		entries, decodeErr := decodeEntries(payload)
+		if decodeErr != nil {
+			return decodeErr
+		}
+		for _, e := range entries {
+			if restoreErr := s.restore(e); restoreErr != nil {
+				return restoreErr
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Join(err, w.close())
+	}
+	s.wal = w
+
+	return s, nil
+}
+
+// restore the entry of the snapshot or of the write-ahead log into memory.
+func (s *Store) restore(e *entry) error {
+	if e.Purged {
+		s.mem.Unload(e.ID, e.Seq)
+		return nil
+	}
+	news, revisions, err := e.toNews()
+	if err != nil {
+		return err
+	}
+	s.mem.Load(news, revisions...)
+	return nil
+}
+
+// Create news and log it, replayed creates are not logged again.
+func (s *Store) Create(ctx context.Context, news *memstore.News) (*memstore.News, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return nil, s.unavailable()
+	}
+	defer s.compact()
+	return s.mem.Create(ctx, news) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// Get news by it's id.
+func (s *Store) Get(ctx context.Context, id uuid.UUID) (*memstore.News, error) {
+	return s.mem.Get(ctx, id) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// GetMany news by their ids in the order of the ids.
+func (s *Store) GetMany(ctx context.Context, ids []uuid.UUID) ([]*memstore.News, error) {
+	return s.mem.GetMany(ctx, ids) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// GetAll news.
+func (s *Store) GetAll(ctx context.Context) ([]*memstore.News, error) {
+	return s.mem.GetAll(ctx) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// List news matching the query ordered by creation time and then by id.
+func (s *Store) List(ctx context.Context, query *memstore.Query) ([]*memstore.News, error) {
+	return s.mem.List(ctx, query) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// Changes returns the news written after the sequence in the order of their
+// last write.
+func (s *Store) Changes(ctx context.Context, after int64, limit int) ([]*memstore.News, error) {
+	return s.mem.Changes(ctx, after, limit) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// Update news and log it.
+func (s *Store) Update(ctx context.Context, updatedNews *memstore.News) (*memstore.News, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return nil, s.unavailable()
+	}
+	defer s.compact()
+	return s.mem.Update(ctx, updatedNews) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// UpdateAll updates the news at once and logs them as a single entry, so
+// that a crash never leaves part of the batch applied.
+func (s *Store) UpdateAll(ctx context.Context, updates []*memstore.News) ([]*memstore.News, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return nil, s.unavailable()
+	}
+	defer s.compact()
+	return s.mem.UpdateAll(ctx, updates) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// Delete news and log it, deleting a deleted news is not logged.
+func (s *Store) Delete(ctx context.Context, id uuid.UUID, version int64) (*memstore.News, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return nil, s.unavailable()
+	}
+	defer s.compact()
+	return s.mem.Delete(ctx, id, version) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// Revisions of the news from the oldest to the latest.
+func (s *Store) Revisions(ctx context.Context, id uuid.UUID) ([]*memstore.Revision, error) {
+	return s.mem.Revisions(ctx, id) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// Revision of the news by its number.
+func (s *Store) Revision(ctx context.Context, id uuid.UUID, number int64) (*memstore.Revision, error) {
+	return s.mem.Revision(ctx, id, number) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// Rollback the news to a previous revision and log it.
+func (s *Store) Rollback(ctx context.Context, id uuid.UUID, number, version int64) (*memstore.News, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return nil, s.unavailable()
+	}
+	defer s.compact()
+	return s.mem.Rollback(ctx, id, number, version) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// Trash returns the soft deleted news deleted after the sequence, in the order
+// of their deletion.
+func (s *Store) Trash(ctx context.Context, after int64, limit int) ([]*memstore.News, error) {
+	return s.mem.Trash(ctx, after, limit) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// Restore a soft deleted news and log it.
+func (s *Store) Restore(ctx context.Context, id uuid.UUID, version int64) (*memstore.News, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return nil, s.unavailable()
+	}
+	defer s.compact()
+	return s.mem.Restore(ctx, id, version) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// Purge soft deleted news permanently and log them as a single entry.
+func (s *Store) Purge(ctx context.Context, ids ...uuid.UUID) ([]*memstore.News, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return nil, s.unavailable()
+	}
+	defer s.compact()
+	return s.mem.Purge(ctx, ids...) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// PurgeDeleted permanently purges the news soft deleted before the time and
+// logs them as a single entry.
+func (s *Store) PurgeDeleted(ctx context.Context, before time.Time) ([]*memstore.News, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return nil, s.unavailable()
+	}
+	defer s.compact()
+	return s.mem.PurgeDeleted(ctx, before) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// Transition moves the news to the next state of the workflow and logs it.
+func (s *Store) Transition(ctx context.Context, id uuid.UUID, transition memstore.Transition, version int64) (*memstore.News, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return nil, s.unavailable()
+	}
+	defer s.compact()
+	return s.mem.Transition(ctx, id, transition, version) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// Release publishes the scheduled news whose release time is reached at the
+// time and logs them as a single entry.
+func (s *Store) Release(ctx context.Context, now time.Time) ([]*memstore.News, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return nil, s.unavailable()
+	}
+	defer s.compact()
+	return s.mem.Release(ctx, now) //nolint:wrapcheck // Same errors as the in-memory store.
+}
+
+// NextRelease returns the release time of the next scheduled news, false when
+// no news is scheduled.
+func (s *Store) NextRelease() (time.Time, bool) {
+	return s.mem.NextRelease()
+}
+
+// Stats returns the number of live and soft deleted news.
+func (s *Store) Stats() memstore.Stats {
+	return s.mem.Stats()
+}
+
+// Snapshot compacts the write-ahead log into a snapshot.
+func (s *Store) Snapshot() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.snapshot()
+}
+
+// Close the store after compacting the write-ahead log.
+func (s *Store) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return errors.Join(s.snapshot(), s.wal.close())
+}
+
+// journal appends the writes of the in-memory store to the write-ahead log.
+// The in-memory store calls it on writes made through the store, under the
+// lock of the store.
+type journal Store
+
+// Append logs the writes as a single entry, so that a crash never leaves part
+// of them applied. A failed append is rewound and fails the writes, unless the
+// log cannot be rewound: then the log may hold the failed writes, so the
+// store stops accepting writes until it is reopened.
+func (j *journal) Append(writes []memstore.Write) error {
+	entries := make([]*entry, 0, len(writes))
+	for _, w := range writes {
+		switch {
+		case w.Purged:
+			entries = append(entries, purgedEntry(w.News.ID, w.News.Seq))
+		case w.Revision != nil:
+			entries = append(entries, toEntry(w.News, []*memstore.Revision{w.Revision}))
+		default:
+			entries = append(entries, toEntry(w.News, nil))
+		}
+	}
+	payload, err := encodeEntries(entries)
+	if err == nil {
+		err = j.wal.append(payload)
+	}
+	if err != nil {
+		err = fmt.Errorf("news %s not durable: %w", entries[0].ID, err)
+		if errors.Is(err, errRewind) {
+			j.err = err
+		}
+		return fmt.Errorf("%w: %w", memstore.ErrUnavailable, err)
+	}
+	j.writes++
+	return nil
+}
+
+// compact the write-ahead log into a snapshot once enough writes were logged,
+// the caller must hold the lock. The writes are already durable in the log,
+// a failed compaction is retried on the next write.
+func (s *Store) compact() {
+	if s.writes < s.snapshotEvery {
+		return
+	}
+	if err := s.snapshot(); err != nil {
+		log.Printf("diskstore: %v", err)
+	}
+}
+
+func (s *Store) unavailable() error {
+	return fmt.Errorf("%w: %w", memstore.ErrUnavailable, s.err)
+}
+
+// snapshot writes every news to the snapshot and empties the write-ahead
+// log, the caller must hold the lock.
+func (s *Store) snapshot() error {
+	all := s.mem.All()
+	entries := make([]*entry, 0, len(all)+1)
+	// The sequence of the latest purged news outlives them, so that expired
+	// changes are still detected.
+	if purged := s.mem.Purged(); purged > 0 {
+		entries = append(entries, purgedEntry(uuid.Nil, purged))
+	}
+	for _, news := range all {
+		entries = append(entries, toEntry(news, s.mem.History(news.ID)))
+	}
+	if err := writeSnapshot(filepath.Join(s.dir, snapshotFile), entries); err != nil {
+		return err
+	}
+	s.writes = 0
+	return s.wal.reset()
+}
@@ -0,0 +1,238 @@
+package diskstore_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/codeandlearn1991/news-grpc/internal/diskstore"
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+)
+
+const walFile = "wal.log"
+
+// crashedStore creates news in a store and returns a directory holding the
+// files of the store as a crash would have left them, before the store is
+// closed and compacted.
+func crashedStore(t *testing.T, cfg diskstore.Config, titles ...string) string {
+	t.Helper()
+
+	cfg.Dir = t.TempDir()
+	store, err := diskstore.Open(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := url.Parse("https://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, title := range titles {
+		if _, err = store.Create(t.Context(), &memstore.News{
+			Author:  "author",
+			Title:   title,
+			Summary: "summary",
+			Content: "content",
+			Source:  source,
+			Tags:    []string{"tag"},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	crashed := crash(t, cfg.Dir)
+	if err = store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return crashed
+}
+
+// crash returns a copy of the files of the store in the directory, as a
+// crash would have left them.
+func crash(t *testing.T, dir string) string {
+	t.Helper()
+
+	crashed := t.TempDir()
+	for _, name := range []string{"snapshot.jsonl", walFile} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = os.WriteFile(filepath.Join(crashed, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return crashed
+}
+
+// titlesOf the live news of the store, in creation order.
+func titlesOf(t *testing.T, store *diskstore.Store) []string {
+	t.Helper()
+
+	news, err := store.List(t.Context(), &memstore.Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	titles := make([]string, 0, len(news))
+	for _, n := range news {
+		titles = append(titles, n.Title)
+	}
+	return titles
+}
+
+func TestOpenReplaysTheLog(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		snapshotEvery int
+	}{
+		{"log only", 100},
+		{"snapshot and log", 2},
+		{"snapshot only", 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := crashedStore(t, diskstore.Config{SnapshotEvery: tc.snapshotEvery}, "first", "second", "third")
+
+			store, err := diskstore.Open(diskstore.Config{Dir: dir})
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer store.Close()
+
+			want := []string{"first", "second", "third"}
+			if got := titlesOf(t, store); !slices.Equal(got, want) {
+				t.Errorf("Open() news = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestOpenDamagedLog(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// damage edits the log, given the offset of its last entry.
+		damage   func(log []byte, last int) []byte
+		wantNews []string
+		wantErr  error
+	}{
+		{
+			name: "torn header",
+			damage: func(log []byte, _ int) []byte {
+				return append(log, 1, 2, 3, 4, 5)
+			},
+			wantNews: []string{"first", "second", "third"},
+		},
+		{
+			name: "zeroed header",
+			damage: func(log []byte, _ int) []byte {
+				return append(log, make([]byte, 12)...)
+			},
+			wantNews: []string{"first", "second", "third"},
+		},
+		{
+			name: "torn payload",
+			damage: func(log []byte, _ int) []byte {
+				return log[:len(log)-3]
+			},
+			wantNews: []string{"first", "second"},
+		},
+		{
+			name: "damaged payload at the tail",
+			damage: func(log []byte, _ int) []byte {
+				log[len(log)-1] ^= 0xff
+				return log
+			},
+			wantNews: []string{"first", "second"},
+		},
+		{
+			name: "damaged length at the tail",
+			damage: func(log []byte, last int) []byte {
+				log[last] ^= 0xff
+				return log
+			},
+			wantErr: diskstore.ErrCorrupted,
+		},
+		{
+			name: "damaged length",
+			damage: func(log []byte, _ int) []byte {
+				log[0] ^= 0xff
+				return log
+			},
+			wantErr: diskstore.ErrCorrupted,
+		},
+		{
+			name: "damaged payload",
+			damage: func(log []byte, _ int) []byte {
+				log[12] ^= 0xff
+				return log
+			},
+			wantErr: diskstore.ErrCorrupted,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := crashedStore(t, diskstore.Config{}, "first", "second", "third")
+			path := filepath.Join(dir, walFile)
+			log, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = os.WriteFile(path, tc.damage(log, lastEntry(t, log)), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			store, err := diskstore.Open(diskstore.Config{Dir: dir})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Open() error = %v, want %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer store.Close()
+
+			if got := titlesOf(t, store); !slices.Equal(got, tc.wantNews) {
+				t.Errorf("Open() news = %q, want %q", got, tc.wantNews)
+			}
+			assertAppends(t, dir, store, append(tc.wantNews, "fourth"))
+		})
+	}
+}
+
+// lastEntry returns the offset of the last entry of the log.
+func lastEntry(t *testing.T, log []byte) int {
+	t.Helper()
+
+	offset := 0
+	for {
+		next := offset + 12 + int(binary.LittleEndian.Uint32(log[offset:]))
+		if next >= len(log) {
+			return offset
+		}
+		offset = next
+	}
+}
+
+// assertAppends checks that the store logs new writes after its repaired
+// log, so that they survive another crash.
+func assertAppends(t *testing.T, dir string, store *diskstore.Store, want []string) {
+	t.Helper()
+
+	if _, err := store.Create(t.Context(), &memstore.News{Title: want[len(want)-1]}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	reopened, err := diskstore.Open(diskstore.Config{Dir: crash(t, dir)})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer reopened.Close()
+
+	if got := titlesOf(t, reopened); !slices.Equal(got, want) {
+		t.Errorf("Open() news = %q, want %q", got, want)
+	}
+}
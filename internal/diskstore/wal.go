@@ -0,0 +1,190 @@
+package diskstore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// headerSize of a log entry. Every entry is framed as a little endian uint32
+// payload length, followed by the CRC-32C of the length, the CRC-32C of the
+// length and the payload, and the payload.
+const headerSize = 12
+
+// ErrCorrupted is returned when the write-ahead log is damaged anywhere but
+// at its tail.
+var ErrCorrupted = errors.New("write-ahead log corrupted")
+
+// errRewind is returned when a failed append cannot be rewound, leaving the
+// tail of the log unknown.
+var errRewind = errors.New("write-ahead log not rewound")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// wal is an append only log of checksummed entries.
+type wal struct {
+	file *os.File
+	size int64
+}
+
+func openWAL(path string) (*wal, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open write-ahead log: %w", err)
+	}
+	return &wal{file: file}, nil
+}
+
+// replay calls apply for every entry of the log in order. An incomplete or
+// mismatching entry at the tail, left by a torn write, is truncated. As the
+// length of every entry is checksummed, a damaged entry followed by others is
+// never mistaken for the tail.
+func (w *wal) replay(apply func(payload []byte) error) error {
+	info, err := w.file.Stat()
+	if err != nil {
+		return fmt.Errorf("stat write-ahead log: %w", err)
+	}
+	fileSize := info.Size()
+
+	if _, err = w.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("seek write-ahead log: %w", err)
+	}
+
+	reader := bufio.NewReader(w.file)
+	var offset int64
+	for {
+		var payload []byte
+		if payload, err = readEntry(reader, offset, fileSize); err != nil {
+			break
+		}
+		if err = apply(payload); err != nil {
+			return fmt.Errorf("%w: entry at offset %d: %w", ErrCorrupted, offset, err)
+		}
+		offset += headerSize + int64(len(payload))
+	}
+
+	switch {
+	case errors.Is(err, io.EOF):
+	case errors.Is(err, ErrCorrupted):
+		return err
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		if err = w.file.Truncate(offset); err != nil {
+			return fmt.Errorf("truncate torn write-ahead log tail: %w", err)
+		}
+	default:
+		return fmt.Errorf("read write-ahead log: %w", err)
+	}
+
+	w.size = offset
+	if _, err = w.file.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("seek write-ahead log: %w", err)
+	}
+	return nil
+}
+
+// readEntry reads the payload of the entry at the offset of the log. It fails
+// with io.EOF at the end of the log, with io.ErrUnexpectedEOF when the entry
+// is the torn tail of the log and with ErrCorrupted when it is damaged.
+func readEntry(reader *bufio.Reader, offset, fileSize int64) ([]byte, error) {
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, err //nolint:wrapcheck // The end of the log is told by io.EOF.
+	}
+
+	if crc32.Checksum(header[0:4], crcTable) != binary.LittleEndian.Uint32(header[4:8]) {
+		if offset+headerSize < fileSize {
+			return nil, fmt.Errorf("%w: header checksum mismatch at offset %d", ErrCorrupted, offset)
+		}
+		return nil, io.ErrUnexpectedEOF
+	}
+	length := int64(binary.LittleEndian.Uint32(header[0:4]))
+	if offset+headerSize+length > fileSize {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return nil, err //nolint:wrapcheck // The end of the log is told by io.EOF.
+	}
+
+	if checksum(header[0:4], payload) != binary.LittleEndian.Uint32(header[8:12]) {
+		if offset+headerSize+length < fileSize {
+			return nil, fmt.Errorf("%w: checksum mismatch at offset %d", ErrCorrupted, offset)
+		}
+		return nil, io.ErrUnexpectedEOF
+	}
+	return payload, nil
+}
+
+// checksum of the entry made of the encoded length and the payload.
+func checksum(length, payload []byte) uint32 {
+	return crc32.Update(crc32.Checksum(length, crcTable), crcTable, payload)
+}
+
+// append the payload to the log and flush it to stable storage. A failed
+// append is rewound so that the log is left as it was, and fails with
+// errRewind when it cannot be.
+func (w *wal) append(payload []byte) error {
+	entry := make([]byte, headerSize+len(payload))
+	binary.LittleEndian.PutUint32(entry[0:4], uint32(len(payload))) //nolint:gosec // Entries are far below 4GiB.
+	binary.LittleEndian.PutUint32(entry[4:8], crc32.Checksum(entry[0:4], crcTable))
+	binary.LittleEndian.PutUint32(entry[8:12], checksum(entry[0:4], payload))
+	copy(entry[headerSize:], payload)
+
+	err := w.write(entry)
+	if err == nil {
+		w.size += int64(len(entry))
+		return nil
+	}
+	// Drop the entry, written or not, so that it is neither replayed nor
+	// followed by later appends.
+	if rewindErr := w.rewind(); rewindErr != nil {
+		return errors.Join(err, fmt.Errorf("%w: %w", errRewind, rewindErr))
+	}
+	return err
+}
+
+// write the entry at the end of the log and sync it.
+func (w *wal) write(entry []byte) error {
+	if _, err := w.file.Write(entry); err != nil {
+		return fmt.Errorf("append to write-ahead log: %w", err)
+	}
+	if err := w.file.Sync(); err != nil {
+		return fmt.Errorf("sync write-ahead log: %w", err)
+	}
+	return nil
+}
+
+// rewind the log to the end of the last complete entry.
+func (w *wal) rewind() error {
+	if err := w.file.Truncate(w.size); err != nil {
+		return fmt.Errorf("truncate write-ahead log: %w", err)
+	}
+	if _, err := w.file.Seek(w.size, io.SeekStart); err != nil {
+		return fmt.Errorf("seek write-ahead log: %w", err)
+	}
+	return nil
+}
+
+// reset empties the log once its entries are covered by a snapshot.
+func (w *wal) reset() error {
+	w.size = 0
+	if err := w.rewind(); err != nil {
+		return err
+	}
+	if err := w.file.Sync(); err != nil {
+		return fmt.Errorf("sync write-ahead log: %w", err)
+	}
+	return nil
+}
+
+func (w *wal) close() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("close write-ahead log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Package gateway serves NewsService as JSON over HTTP by transcoding the
+// requests to the gRPC server, along with the OpenAPI document of the mapping.
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/internal/logging"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+)
+
+// OpenAPIPath the OpenAPI document is served at.
+const OpenAPIPath = "/openapi.json"
+
+// New returns the handler of the gateway, forwarding the requests to the gRPC
+// server of the connection. Server streams are written as newline-delimited
+// JSON, one object per message.
+func New(ctx context.Context, conn *grpc.ClientConn) (*http.ServeMux, error) {
+	gwMux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader),
+	)
+	if err := newsv1.RegisterNewsServiceHandler(ctx, gwMux, conn); err != nil {
+		return nil, fmt.Errorf("register news service handler: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", gwMux)
+	mux.HandleFunc("GET "+OpenAPIPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(newsv1.OpenAPI); err != nil {
+			log.Printf("gateway: write openapi document: %v", err)
+		}
+	})
+	return mux, nil
+}
+
+// incomingHeader forwards the request ID header as is, and the other headers
+// as the gateway does by default.
+func incomingHeader(key string) (string, bool) {
+	if strings.EqualFold(key, logging.RequestIDKey) {
+		return logging.RequestIDKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeader responds with the request ID header as is, and with the other
+// metadata prefixed as the gateway does by default.
+func outgoingHeader(key string) (string, bool) {
+	if key == logging.RequestIDKey {
+		return http.CanonicalHeaderKey(key), true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
@@ -0,0 +1,293 @@
+package gateway_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/internal/gateway"
+	ingrpc "github.com/codeandlearn1991/news-grpc/internal/grpc"
+	"github.com/codeandlearn1991/news-grpc/internal/logging"
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newGateway returns the URL of a gateway of a news server of the editors,
+// which logs its calls so that it sends their request ID back.
+func newGateway(t *testing.T) string {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	logger := slog.New(slog.DiscardHandler)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(logger)),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(logger)),
+	)
+	newsv1.RegisterNewsServiceServer(srv, ingrpc.NewServer(memstore.New(),
+		ingrpc.WithPrivileged(func(context.Context) bool { return true }),
+	))
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(lis) }()
+	t.Cleanup(func() {
+		srv.Stop()
+		if err := <-served; err != nil {
+			t.Errorf("Serve() error = %v", err)
+		}
+	})
+
+	conn, err := grpc.NewClient("passthrough:///local",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if closeErr := conn.Close(); closeErr != nil {
+			t.Errorf("Close() error = %v", closeErr)
+		}
+	})
+
+	handler, err := gateway.New(t.Context(), conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpSrv := httptest.NewServer(handler)
+	t.Cleanup(httpSrv.Close)
+	return httpSrv.URL
+}
+
+// do the request of the method to the URL with the JSON body, returning the
+// response along with its body.
+func do(t *testing.T, method, url, body string, header http.Header) (res *http.Response, resBody []byte) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	res, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if resBody, err = io.ReadAll(res.Body); err != nil {
+		t.Fatal(err)
+	}
+	return res, resBody
+}
+
+func TestGatewayRoutes(t *testing.T) {
+	url := newGateway(t)
+	id := createNews(t, url)
+
+	for _, tc := range []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		// wantTitle of the news of the response, if any.
+		wantTitle string
+	}{
+		{
+			name:       "get",
+			method:     http.MethodGet,
+			path:       "/v1/news/" + id,
+			wantStatus: http.StatusOK,
+			wantTitle:  "a valid title",
+		},
+		{
+			name:       "get unknown",
+			method:     http.MethodGet,
+			path:       "/v1/news/" + uuid.NewString(),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "get invalid id",
+			method:     http.MethodGet,
+			path:       "/v1/news/not-a-uuid",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "update the masked fields",
+			method:     http.MethodPatch,
+			path:       "/v1/news/" + id + "?update_mask=title",
+			body:       `{"title": "an updated title", "author": "ignored author"}`,
+			wantStatus: http.StatusOK,
+			wantTitle:  "an updated title",
+		},
+		{
+			name:       "update a field that is not updatable",
+			method:     http.MethodPatch,
+			path:       "/v1/news/" + id + "?update_mask=version",
+			body:       `{"version": 7}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "revisions",
+			method:     http.MethodGet,
+			path:       "/v1/news/" + id + "/revisions",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unknown route",
+			method:     http.MethodGet,
+			path:       "/v1/unknown",
+			wantStatus: http.StatusNotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, body := do(t, tc.method, url+tc.path, tc.body, nil)
+			if res.StatusCode != tc.wantStatus {
+				t.Fatalf("%s %s status = %d, want %d: %s", tc.method, tc.path, res.StatusCode, tc.wantStatus, body)
+			}
+			if got := res.Header.Get("Content-Type"); got != "application/json" {
+				t.Errorf("%s %s content type = %q, want %q", tc.method, tc.path, got, "application/json")
+			}
+			if tc.wantTitle == "" {
+				return
+			}
+			var news struct {
+				Title  string `json:"title"`
+				Author string `json:"author"`
+			}
+			if err := json.Unmarshal(body, &news); err != nil {
+				t.Fatal(err)
+			}
+			if news.Title != tc.wantTitle || news.Author != "author" {
+				t.Errorf("%s %s = %q by %q, want %q by %q", tc.method, tc.path, news.Title, news.Author, tc.wantTitle, "author")
+			}
+		})
+	}
+}
+
+// createNews through the gateway, returning its id.
+func createNews(t *testing.T, url string) string {
+	t.Helper()
+
+	id := uuid.NewString()
+	res, body := do(t, http.MethodPost, url+"/v1/news", `{
+		"id": "`+id+`",
+		"author": "author",
+		"title": "a valid title",
+		"summary": "a summary long enough",
+		"content": "some content",
+		"source": "https://example.com",
+		"tags": ["tag"]
+	}`, nil)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("POST /v1/news status = %d, want %d: %s", res.StatusCode, http.StatusOK, body)
+	}
+	return id
+}
+
+func TestGatewayStreams(t *testing.T) {
+	url := newGateway(t)
+	ids := []string{createNews(t, url), uuid.NewString(), createNews(t, url)}
+
+	// Client streams are read from newline-delimited JSON, and server streams
+	// written as such, one object per message.
+	var reqs strings.Builder
+	for _, id := range ids {
+		reqs.WriteString(`{"id": "` + id + `"}` + "\n")
+	}
+	res, body := do(t, http.MethodPost, url+"/v1/news:batchDelete", reqs.String(), nil)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("POST /v1/news:batchDelete status = %d, want %d: %s", res.StatusCode, http.StatusOK, body)
+	}
+	wantOutcomes := []string{"OUTCOME_DELETED", "OUTCOME_NOT_FOUND", "OUTCOME_DELETED"}
+	gotIDs := make([]string, 0, len(ids))
+	gotOutcomes := make([]string, 0, len(ids))
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		var line struct {
+			Result struct {
+				ID      string `json:"id"`
+				Outcome string `json:"outcome"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("POST /v1/news:batchDelete line %q: %v", scanner.Text(), err)
+		}
+		gotIDs = append(gotIDs, line.Result.ID)
+		gotOutcomes = append(gotOutcomes, line.Result.Outcome)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(gotIDs, ids) || !slices.Equal(gotOutcomes, wantOutcomes) {
+		t.Errorf("POST /v1/news:batchDelete = %q with %q, want %q with %q", gotOutcomes, gotIDs, wantOutcomes, ids)
+	}
+
+	// The news are deleted, none is left to stream.
+	res, body = do(t, http.MethodGet, url+"/v1/news:stream", "", nil)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("GET /v1/news:stream status = %d, want %d: %s", res.StatusCode, http.StatusOK, body)
+	}
+	if len(bytes.TrimSpace(body)) != 0 {
+		t.Errorf("GET /v1/news:stream = %s, want no news", body)
+	}
+}
+
+func TestGatewayRequestID(t *testing.T) {
+	url := newGateway(t)
+	for _, tc := range []struct {
+		name string
+		id   string
+	}{
+		{name: "forwarded", id: "request-1"},
+		{name: "generated"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			if tc.id != "" {
+				header.Set(logging.RequestIDKey, tc.id)
+			}
+			res, _ := do(t, http.MethodGet, url+"/v1/news/"+uuid.NewString(), "", header)
+			got := res.Header.Get(logging.RequestIDKey)
+			if tc.id != "" && got != tc.id || got == "" {
+				t.Errorf("request id header = %q, want %q or a generated one when empty", got, tc.id)
+			}
+		})
+	}
+}
+
+func TestGatewayOpenAPI(t *testing.T) {
+	res, body := do(t, http.MethodGet, newGateway(t)+gateway.OpenAPIPath, "", nil)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("GET %s status = %d, want %d", gateway.OpenAPIPath, res.StatusCode, http.StatusOK)
+	}
+	if got := res.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("GET %s content type = %q, want %q", gateway.OpenAPIPath, got, "application/json")
+	}
+	var document struct {
+		Paths map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(body, &document); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"/v1/news", "/v1/news/{id}", "/v1/news/{news.id}"} {
+		if _, ok := document.Paths[path]; !ok {
+			t.Errorf("GET %s has no path %s", gateway.OpenAPIPath, path)
+		}
+	}
+}
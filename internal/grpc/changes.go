@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+)
+
+// ListChanges returns a page of the changes of the news since the cursor of
+// the request, in commit order. The news the caller may not read are
+// redacted.
+func (s *Server) ListChanges(ctx context.Context, in *newsv1.ListChangesRequest) (*newsv1.ListChangesResponse, error) {
+	pageSize := int(in.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	var after int64
+	if in.SinceCursor != "" {
+		var err error
+		if after, err = decodeChangeCursor(in.SinceCursor); err != nil {
+			return nil, invalidField("since_cursor", err)
+		}
+	}
+
+	// One more than the page size tells whether more changes exist.
+	changedNews, err := s.store.Changes(ctx, after, pageSize+1)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &newsv1.ListChangesResponse{}
+	if len(changedNews) > pageSize {
+		changedNews = changedNews[:pageSize]
+		res.HasMore = true
+	}
+
+	privileged := s.privileged(ctx)
+	res.Changes = make([]*newsv1.Change, 0, len(changedNews))
+	for _, news := range changedNews {
+		res.Changes = append(res.Changes, &newsv1.Change{
+			Seq:  news.Seq,
+			Type: toChangeType(memstore.ChangeOf(news)),
+			News: toVisibleNews(news, privileged),
+		})
+		after = news.Seq
+	}
+	res.NextCursor = encodeChangeCursor(after)
+
+	return res, nil
+}
+
+func toChangeType(change memstore.Change) newsv1.ChangeType {
+	switch change {
+	case memstore.ChangeCreated:
+		return newsv1.ChangeType_CHANGE_TYPE_CREATED
+	case memstore.ChangeUpdated:
+		return newsv1.ChangeType_CHANGE_TYPE_UPDATED
+	case memstore.ChangeDeleted:
+		return newsv1.ChangeType_CHANGE_TYPE_DELETED
+	default:
+		return newsv1.ChangeType_CHANGE_TYPE_UNSPECIFIED
+	}
+}
+
+// encodeChangeCursor into an opaque string holding the sequence.
+func encodeChangeCursor(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(seq, 10)))
+}
+
+func decodeChangeCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	seq, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || seq < 0 {
+		return 0, fmt.Errorf("invalid cursor: %q", raw)
+	}
+
+	return seq, nil
+}
@@ -0,0 +1,180 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/internal/diskstore"
+	ingrpc "github.com/codeandlearn1991/news-grpc/internal/grpc"
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// restart the store in the directory and returns the store reopened.
+type restart func(t *testing.T, store *diskstore.Store, dir string) *diskstore.Store
+
+// closeAndOpen restarts the store after closing it, so that it is reopened
+// from its snapshot.
+func closeAndOpen(t *testing.T, store *diskstore.Store, dir string) *diskstore.Store {
+	t.Helper()
+
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return openDiskStore(t, dir)
+}
+
+// crashAndOpen restarts the store from a copy of its files, as a crash would
+// have left them, so that it is reopened from its write-ahead log.
+func crashAndOpen(t *testing.T, store *diskstore.Store, dir string) *diskstore.Store {
+	t.Helper()
+
+	crashed := t.TempDir()
+	for _, name := range []string{"snapshot.jsonl", "wal.log"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = os.WriteFile(filepath.Join(crashed, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return openDiskStore(t, crashed)
+}
+
+func openDiskStore(t *testing.T, dir string) *diskstore.Store {
+	t.Helper()
+
+	store, err := diskstore.Open(diskstore.Config{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestListChangesCursorAcrossRestart(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		restart restart
+		// purge the second news before the restart.
+		purge     bool
+		wantTypes []newsv1.ChangeType
+		wantCode  codes.Code
+	}{
+		{
+			name:    "close",
+			restart: closeAndOpen,
+			wantTypes: []newsv1.ChangeType{
+				newsv1.ChangeType_CHANGE_TYPE_UPDATED,
+				newsv1.ChangeType_CHANGE_TYPE_CREATED,
+			},
+		},
+		{
+			name:    "crash",
+			restart: crashAndOpen,
+			wantTypes: []newsv1.ChangeType{
+				newsv1.ChangeType_CHANGE_TYPE_UPDATED,
+				newsv1.ChangeType_CHANGE_TYPE_CREATED,
+			},
+		},
+		{
+			name:     "close after purge",
+			restart:  closeAndOpen,
+			purge:    true,
+			wantCode: codes.OutOfRange,
+		},
+		{
+			name:     "crash after purge",
+			restart:  crashAndOpen,
+			purge:    true,
+			wantCode: codes.OutOfRange,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			dir := t.TempDir()
+			store := openDiskStore(t, dir)
+
+			first := createNews(ctx, t, store, "first")
+			second := createNews(ctx, t, store, "second")
+			res, err := ingrpc.NewServer(store).ListChanges(ctx, &newsv1.ListChangesRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			cursor := res.GetNextCursor()
+
+			updated := *first
+			updated.Title = "first updated"
+			if _, err = store.Update(ctx, &updated); err != nil {
+				t.Fatal(err)
+			}
+			if tc.purge {
+				if _, err = store.Delete(ctx, second.ID, 0); err != nil {
+					t.Fatal(err)
+				}
+				if _, err = store.Purge(ctx, second.ID); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			store = tc.restart(t, store, dir)
+			defer store.Close()
+			third := createNews(ctx, t, store, "third")
+
+			res, err = ingrpc.NewServer(store).ListChanges(ctx, &newsv1.ListChangesRequest{SinceCursor: cursor})
+			if got := status.Code(err); got != tc.wantCode {
+				t.Fatalf("ListChanges() code = %s, want %s", got, tc.wantCode)
+			}
+			if err != nil {
+				return
+			}
+
+			var gotIDs []string
+			var gotTypes []newsv1.ChangeType
+			var seqs []int64
+			for _, change := range res.GetChanges() {
+				gotIDs = append(gotIDs, change.GetNews().GetId())
+				gotTypes = append(gotTypes, change.GetType())
+				seqs = append(seqs, change.GetSeq())
+			}
+			wantIDs := []string{first.ID.String(), third.ID.String()}
+			if !slices.Equal(gotIDs, wantIDs) {
+				t.Errorf("ListChanges() ids = %q, want %q", gotIDs, wantIDs)
+			}
+			if !slices.Equal(gotTypes, tc.wantTypes) {
+				t.Errorf("ListChanges() types = %v, want %v", gotTypes, tc.wantTypes)
+			}
+			// The sequences before the restart are not reused.
+			if wantSeqs := []int64{second.Seq + 1, second.Seq + 2}; !slices.Equal(seqs, wantSeqs) {
+				t.Errorf("ListChanges() seqs = %v, want %v", seqs, wantSeqs)
+			}
+		})
+	}
+}
+
+func createNews(ctx context.Context, t *testing.T, store ingrpc.NewsWriter, title string) *memstore.News {
+	t.Helper()
+
+	news, err := store.Create(ctx, &memstore.News{
+		Title:  title,
+		Source: &url.URL{Scheme: "https", Host: "example.com"},
+		Tags:   []string{"tag"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return news
+}
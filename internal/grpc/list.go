@@ -0,0 +1,126 @@
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultPageSize = 50
+
+// ListNews returns a page of news matching the filters of the request.
+func (s *Server) ListNews(ctx context.Context, in *newsv1.ListNewsRequest) (*newsv1.ListNewsResponse, error) {
+	pageSize := int(in.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	states, err := s.listedStates(ctx, in.States)
+	if err != nil {
+		return nil, err
+	}
+	query := memstore.Query{
+		Tag:           in.Tag,
+		Author:        in.Author,
+		States:        states,
+		CreatedAfter:  toTime(in.CreatedAfter),
+		CreatedBefore: toTime(in.CreatedBefore),
+		UpdatedAfter:  toTime(in.UpdatedAfter),
+		UpdatedBefore: toTime(in.UpdatedBefore),
+		// One more than the page size tells whether a next page exists.
+		Limit: pageSize + 1,
+	}
+
+	if in.PageToken != "" {
+		var cursor *memstore.Cursor
+		if cursor, err = decodePageToken(in.PageToken); err != nil {
+			return nil, invalidField("page_token", err)
+		}
+		query.After = cursor
+	}
+
+	fetchedNews, err := s.store.List(ctx, &query)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &newsv1.ListNewsResponse{}
+	if len(fetchedNews) > pageSize {
+		fetchedNews = fetchedNews[:pageSize]
+		last := fetchedNews[len(fetchedNews)-1]
+		res.NextPageToken = encodePageToken(memstore.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	res.News = make([]*newsv1.News, 0, len(fetchedNews))
+	privileged := s.privileged(ctx)
+	for _, n := range fetchedNews {
+		// Pages may come out short, the token still moves past the hidden news.
+		if !readable(n, privileged) {
+			continue
+		}
+		res.News = append(res.News, toNews(n))
+	}
+
+	return res, nil
+}
+
+// listedStates returns the states of the news the caller lists. Only the
+// privileged callers may list the news that are not published.
+func (s *Server) listedStates(ctx context.Context, states []newsv1.State) ([]memstore.State, error) {
+	if !s.privileged(ctx) {
+		for _, state := range states {
+			if state != newsv1.State_STATE_PUBLISHED {
+				return nil, status.Errorf(codes.PermissionDenied, "listing the news in state %s is reserved to the editors", state)
+			}
+		}
+	}
+	return fromStates(states), nil
+}
+
+// encodePageToken into an opaque string of the form "<unix nano>:<id>".
+func encodePageToken(cursor memstore.Cursor) string {
+	raw := strconv.FormatInt(cursor.CreatedAt.UnixNano(), 10) + ":" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePageToken(token string) (*memstore.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, errors.New("invalid page token: malformed cursor")
+	}
+
+	createdAt, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+
+	return &memstore.Cursor{CreatedAt: time.Unix(0, createdAt).UTC(), ID: parsedID}, nil
+}
+
+func toTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,249 @@
+package grpc_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	ingrpc "github.com/codeandlearn1991/news-grpc/internal/grpc"
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newListStore returns a store with ten news, by two authors and tagged odd
+// or even, the first eight of them published. The news are returned in the
+// listing order.
+func newListStore(t *testing.T) (*memstore.Store, []*memstore.News) {
+	t.Helper()
+
+	ctx := t.Context()
+	source, err := url.Parse("https://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := memstore.New()
+	created := make([]*memstore.News, 0, 10)
+	for i := range 10 {
+		tag := "even"
+		if i%2 == 1 {
+			tag = "odd"
+		}
+		news, err := store.Create(ctx, &memstore.News{
+			Author:  fmt.Sprintf("author %d", i%2),
+			Title:   fmt.Sprintf("title %d", i),
+			Summary: fmt.Sprintf("summary %d", i),
+			Content: fmt.Sprintf("content %d", i),
+			Source:  source,
+			Tags:    []string{tag, "all"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i < 8 {
+			if news, err = store.Transition(ctx, news.ID, memstore.TransitionPublish, 0); err != nil {
+				t.Fatal(err)
+			}
+		}
+		created = append(created, news)
+		// Tells the creation times apart on coarse clocks.
+		time.Sleep(time.Millisecond)
+	}
+	return store, created
+}
+
+// listAll walks the pages of the listing and returns the titles of the news
+// along with the number of pages.
+func listAll(ctx context.Context, t *testing.T, server *ingrpc.Server, req *newsv1.ListNewsRequest) (titles []string, pages int) {
+	t.Helper()
+
+	for {
+		res, err := server.ListNews(ctx, req)
+		if err != nil {
+			t.Fatalf("ListNews() error = %v", err)
+		}
+		pages++
+		for _, news := range res.GetNews() {
+			titles = append(titles, news.GetTitle())
+		}
+		if res.GetNextPageToken() == "" {
+			return titles, pages
+		}
+		req.PageToken = res.GetNextPageToken()
+	}
+}
+
+func TestListNews(t *testing.T) {
+	store, created := newListStore(t)
+	reader := ingrpc.NewServer(store)
+	editor := ingrpc.NewServer(store, ingrpc.WithPrivileged(func(context.Context) bool { return true }))
+
+	titles := func(indexes ...int) []string {
+		result := make([]string, 0, len(indexes))
+		for _, i := range indexes {
+			result = append(result, created[i].Title)
+		}
+		return result
+	}
+
+	for _, tc := range []struct {
+		name      string
+		server    *ingrpc.Server
+		req       *newsv1.ListNewsRequest
+		wantNews  []string
+		wantPages int
+		wantCode  codes.Code
+	}{
+		{
+			name:      "published by default",
+			req:       &newsv1.ListNewsRequest{},
+			wantNews:  titles(0, 1, 2, 3, 4, 5, 6, 7),
+			wantPages: 1,
+		},
+		{
+			name:      "pages in creation order",
+			req:       &newsv1.ListNewsRequest{PageSize: 3},
+			wantNews:  titles(0, 1, 2, 3, 4, 5, 6, 7),
+			wantPages: 3,
+		},
+		{
+			name:      "page size dividing the news",
+			req:       &newsv1.ListNewsRequest{PageSize: 4},
+			wantNews:  titles(0, 1, 2, 3, 4, 5, 6, 7),
+			wantPages: 2,
+		},
+		{
+			name:      "tag",
+			req:       &newsv1.ListNewsRequest{PageSize: 2, Tag: "odd"},
+			wantNews:  titles(1, 3, 5, 7),
+			wantPages: 2,
+		},
+		{
+			name:      "author",
+			req:       &newsv1.ListNewsRequest{Author: "author 0"},
+			wantNews:  titles(0, 2, 4, 6),
+			wantPages: 1,
+		},
+		{
+			name:      "tag and author matching nothing",
+			req:       &newsv1.ListNewsRequest{Tag: "odd", Author: "author 0"},
+			wantNews:  nil,
+			wantPages: 1,
+		},
+		{
+			name:   "published state",
+			server: reader,
+			req: &newsv1.ListNewsRequest{
+				States: []newsv1.State{newsv1.State_STATE_PUBLISHED},
+			},
+			wantNews:  titles(0, 1, 2, 3, 4, 5, 6, 7),
+			wantPages: 1,
+		},
+		{
+			name:   "unpublished states as a reader",
+			server: reader,
+			req: &newsv1.ListNewsRequest{
+				States: []newsv1.State{newsv1.State_STATE_PUBLISHED, newsv1.State_STATE_DRAFT},
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:   "unpublished states as an editor",
+			server: editor,
+			req: &newsv1.ListNewsRequest{
+				States: []newsv1.State{newsv1.State_STATE_DRAFT},
+			},
+			wantNews:  titles(8, 9),
+			wantPages: 1,
+		},
+		{
+			name: "created range",
+			req: &newsv1.ListNewsRequest{
+				PageSize:      2,
+				CreatedAfter:  timestamppb.New(created[2].CreatedAt),
+				CreatedBefore: timestamppb.New(created[6].CreatedAt),
+			},
+			wantNews:  titles(2, 3, 4, 5),
+			wantPages: 2,
+		},
+		{
+			name: "updated after",
+			req: &newsv1.ListNewsRequest{
+				UpdatedAfter: timestamppb.New(created[7].UpdatedAt),
+			},
+			wantNews:  titles(7),
+			wantPages: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := tc.server
+			if server == nil {
+				server = reader
+			}
+			if tc.wantCode != codes.OK {
+				if _, err := server.ListNews(t.Context(), tc.req); status.Code(err) != tc.wantCode {
+					t.Errorf("ListNews() code = %s, want %s", status.Code(err), tc.wantCode)
+				}
+				return
+			}
+
+			gotNews, gotPages := listAll(t.Context(), t, server, tc.req)
+			if !slices.Equal(gotNews, tc.wantNews) {
+				t.Errorf("ListNews() news = %q, want %q", gotNews, tc.wantNews)
+			}
+			if gotPages != tc.wantPages {
+				t.Errorf("ListNews() pages = %d, want %d", gotPages, tc.wantPages)
+			}
+		})
+	}
+}
+
+func TestListNewsPageTokenSurvivesWrites(t *testing.T) {
+	store, created := newListStore(t)
+	server := ingrpc.NewServer(store)
+	ctx := t.Context()
+
+	res, err := server.ListNews(ctx, &newsv1.ListNewsRequest{PageSize: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Deleting a news of the first page must not shift the next one.
+	if _, err = store.Delete(ctx, created[1].ID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	gotNews, _ := listAll(ctx, t, server, &newsv1.ListNewsRequest{PageSize: 4, PageToken: res.GetNextPageToken()})
+	wantNews := []string{created[4].Title, created[5].Title, created[6].Title, created[7].Title}
+	if !slices.Equal(gotNews, wantNews) {
+		t.Errorf("ListNews() news = %q, want %q", gotNews, wantNews)
+	}
+}
+
+func TestListNewsInvalidPageToken(t *testing.T) {
+	store, _ := newListStore(t)
+	server := ingrpc.NewServer(store)
+
+	for _, tc := range []struct {
+		name  string
+		token string
+	}{
+		{"not base64", "not a token!"},
+		{"no separator", "MTIz"},
+		{"no time", "eDphYmM"},
+		{"no id", "MTIzOmFiYw"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := server.ListNews(t.Context(), &newsv1.ListNewsRequest{PageToken: tc.token})
+			if got := status.Code(err); got != codes.InvalidArgument {
+				t.Errorf("ListNews() code = %s, want %s", got, codes.InvalidArgument)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package grpc
+
+import (
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/internal/auth"
+)
+
+// Policy returns the roles allowed to call the methods of the service: the
+// readers read the news, the editors write them and walk them through the
+// workflow, and the admins delete them and manage the trash.
+func Policy() auth.Policy {
+	return auth.Policy{
+		newsv1.NewsService_Get_FullMethodName:          auth.RoleReader,
+		newsv1.NewsService_BatchGetNews_FullMethodName: auth.RoleReader,
+		newsv1.NewsService_GetAll_FullMethodName:       auth.RoleReader,
+		newsv1.NewsService_WatchNews_FullMethodName:    auth.RoleReader,
+		newsv1.NewsService_ListChanges_FullMethodName:  auth.RoleReader,
+		newsv1.NewsService_ListNews_FullMethodName:     auth.RoleReader,
+		newsv1.NewsService_SearchNews_FullMethodName:   auth.RoleReader,
+
+		newsv1.NewsService_Create_FullMethodName:        auth.RoleEditor,
+		newsv1.NewsService_Update_FullMethodName:        auth.RoleEditor,
+		newsv1.NewsService_UpdateNews_FullMethodName:    auth.RoleEditor,
+		newsv1.NewsService_ListRevisions_FullMethodName: auth.RoleEditor,
+		newsv1.NewsService_GetRevision_FullMethodName:   auth.RoleEditor,
+		newsv1.NewsService_DiffRevisions_FullMethodName: auth.RoleEditor,
+		newsv1.NewsService_RollbackNews_FullMethodName:  auth.RoleEditor,
+		newsv1.NewsService_SubmitNews_FullMethodName:    auth.RoleEditor,
+		newsv1.NewsService_ApproveNews_FullMethodName:   auth.RoleEditor,
+		newsv1.NewsService_RejectNews_FullMethodName:    auth.RoleEditor,
+		newsv1.NewsService_PublishNews_FullMethodName:   auth.RoleEditor,
+		newsv1.NewsService_ArchiveNews_FullMethodName:   auth.RoleEditor,
+
+		newsv1.NewsService_DeletedNews_FullMethodName: auth.RoleAdmin,
+		newsv1.NewsService_ListTrash_FullMethodName:   auth.RoleAdmin,
+		newsv1.NewsService_RestoreNews_FullMethodName: auth.RoleAdmin,
+		newsv1.NewsService_PurgeNews_FullMethodName:   auth.RoleAdmin,
+		newsv1.NewsService_EmptyTrash_FullMethodName:  auth.RoleAdmin,
+	}
+}
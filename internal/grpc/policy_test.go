@@ -0,0 +1,184 @@
+package grpc_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/internal/auth"
+	ingrpc "github.com/codeandlearn1991/news-grpc/internal/grpc"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// newVerifier returns a verifier of the tokens signed by the key, along with
+// the key.
+func newVerifier(t *testing.T) (*auth.Verifier, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &key.PublicKey, KeyID: "key", Algorithm: string(jose.ES256), Use: "sig"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err = os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	verifier, err := auth.NewVerifier(path, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return verifier, key
+}
+
+// withToken returns the context of a call bearing a token of the role signed
+// by the key, or without a token for the public role.
+func withToken(ctx context.Context, t *testing.T, key *ecdsa.PrivateKey, role string) context.Context {
+	t.Helper()
+
+	if role == "public" {
+		return ctx
+	}
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "key"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.Signed(signer).
+		Claims(jwt.Claims{Subject: role, Expiry: jwt.NewNumericDate(time.Now().Add(time.Hour))}).
+		Claims(map[string]any{"roles": []string{role}}).
+		Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+}
+
+// serverStream of a call in the context.
+type serverStream struct {
+	grpc.ServerStream
+	//nolint:containedctx // The context of the stream.
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestPolicy(t *testing.T) {
+	reader, editor, admin := auth.RoleReader, auth.RoleEditor, auth.RoleAdmin
+	want := map[string]auth.Role{
+		"Get":           reader,
+		"BatchGetNews":  reader,
+		"GetAll":        reader,
+		"WatchNews":     reader,
+		"ListChanges":   reader,
+		"ListNews":      reader,
+		"SearchNews":    reader,
+		"Create":        editor,
+		"Update":        editor,
+		"UpdateNews":    editor,
+		"ListRevisions": editor,
+		"GetRevision":   editor,
+		"DiffRevisions": editor,
+		"RollbackNews":  editor,
+		"SubmitNews":    editor,
+		"ApproveNews":   editor,
+		"RejectNews":    editor,
+		"PublishNews":   editor,
+		"ArchiveNews":   editor,
+		"DeletedNews":   admin,
+		"ListTrash":     admin,
+		"RestoreNews":   admin,
+		"PurgeNews":     admin,
+		"EmptyTrash":    admin,
+	}
+
+	verifier, key := newVerifier(t)
+	policy := ingrpc.Policy()
+	unary := auth.UnaryServerInterceptor(verifier, policy)
+	stream := auth.StreamServerInterceptor(verifier, policy)
+
+	// call the method with the interceptors as the role, returning the code
+	// of the call.
+	call := func(t *testing.T, method string, isStream bool, role string) codes.Code {
+		t.Helper()
+
+		ctx := withToken(t.Context(), t, key, role)
+		fullMethod := "/" + newsv1.NewsService_ServiceDesc.ServiceName + "/" + method
+		var err error
+		if isStream {
+			err = stream(nil, &serverStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: fullMethod},
+				func(any, grpc.ServerStream) error { return nil })
+		} else {
+			_, err = unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: fullMethod},
+				func(context.Context, any) (any, error) { return struct{}{}, nil })
+		}
+		return status.Code(err)
+	}
+
+	methods := make(map[string]bool)
+	for _, method := range newsv1.NewsService_ServiceDesc.Methods {
+		methods[method.MethodName] = false
+	}
+	for _, desc := range newsv1.NewsService_ServiceDesc.Streams {
+		methods[desc.StreamName] = true
+	}
+	if len(methods) != len(want) {
+		t.Errorf("service has %d methods, want %d", len(methods), len(want))
+	}
+
+	for method, isStream := range methods {
+		required, ok := want[method]
+		if !ok {
+			t.Errorf("method %s has no expected role", method)
+			continue
+		}
+		t.Run(method, func(t *testing.T) {
+			for _, tc := range []struct {
+				role string
+				rank auth.Role
+			}{
+				{"public", auth.RolePublic},
+				{"reader", auth.RoleReader},
+				{"editor", auth.RoleEditor},
+				{"admin", auth.RoleAdmin},
+			} {
+				wantCode := codes.OK
+				switch {
+				case tc.rank == auth.RolePublic:
+					wantCode = codes.Unauthenticated
+				case tc.rank < required:
+					wantCode = codes.PermissionDenied
+				}
+				if got := call(t, method, isStream, tc.role); got != wantCode {
+					t.Errorf("%s as %s: code = %s, want %s", method, tc.role, got, wantCode)
+				}
+			}
+		})
+	}
+
+	t.Run("method out of the policy", func(t *testing.T) {
+		if got := call(t, "Unknown", false, "admin"); got != codes.PermissionDenied {
+			t.Errorf("Unknown as admin: code = %s, want %s", got, codes.PermissionDenied)
+		}
+	})
+}
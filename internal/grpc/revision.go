@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListRevisions returns the revisions of the news from the oldest to the
+// latest.
+func (s *Server) ListRevisions(ctx context.Context, in *newsv1.ListRevisionsRequest) (*newsv1.ListRevisionsResponse, error) {
+	newsUUID, err := uuid.Parse(in.Id)
+	if err != nil {
+		return nil, invalidField("id", err)
+	}
+
+	revisions, err := s.store.Revisions(ctx, newsUUID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &newsv1.ListRevisionsResponse{Revisions: make([]*newsv1.Revision, 0, len(revisions))}
+	for _, revision := range revisions {
+		res.Revisions = append(res.Revisions, toRevision(revision))
+	}
+	return res, nil
+}
+
+// GetRevision returns a revision of the news by its number.
+func (s *Server) GetRevision(ctx context.Context, in *newsv1.GetRevisionRequest) (*newsv1.Revision, error) {
+	newsUUID, err := uuid.Parse(in.Id)
+	if err != nil {
+		return nil, invalidField("id", err)
+	}
+
+	revision, err := s.store.Revision(ctx, newsUUID, in.Revision)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toRevision(revision), nil
+}
+
+// DiffRevisions returns the fields that changed from a revision of the news to
+// another.
+func (s *Server) DiffRevisions(ctx context.Context, in *newsv1.DiffRevisionsRequest) (*newsv1.DiffRevisionsResponse, error) {
+	newsUUID, err := uuid.Parse(in.Id)
+	if err != nil {
+		return nil, invalidField("id", err)
+	}
+
+	from, err := s.store.Revision(ctx, newsUUID, in.FromRevision)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	to, err := s.store.Revision(ctx, newsUUID, in.ToRevision)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	changes := memstore.Diff(from.News, to.News)
+	res := &newsv1.DiffRevisionsResponse{Changes: make([]*newsv1.FieldDiff, 0, len(changes))}
+	for _, change := range changes {
+		res.Changes = append(res.Changes, &newsv1.FieldDiff{Field: change.Field, From: change.From, To: change.To})
+	}
+	return res, nil
+}
+
+// RollbackNews restores the content of a previous revision of the news as a
+// new revision.
+func (s *Server) RollbackNews(ctx context.Context, in *newsv1.RollbackNewsRequest) (*newsv1.News, error) {
+	newsUUID, err := uuid.Parse(in.Id)
+	if err != nil {
+		return nil, invalidField("id", err)
+	}
+
+	rolledBack, err := s.store.Rollback(s.editing(ctx), newsUUID, in.Revision, in.Version)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toNews(rolledBack), nil
+}
+
+func toRevision(revision *memstore.Revision) *newsv1.Revision {
+	return &newsv1.Revision{
+		NewsId:        revision.News.ID.String(),
+		Number:        revision.Number,
+		Author:        revision.Author,
+		CreatedAt:     timestamppb.New(revision.CreatedAt.UTC()),
+		ChangedFields: revision.ChangedFields,
+		News:          toNews(revision.News),
+	}
+}
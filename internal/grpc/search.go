@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SearchNews returns a page of news matching the query ranked by relevance.
+func (s *Server) SearchNews(ctx context.Context, in *newsv1.SearchNewsRequest) (*newsv1.SearchNewsResponse, error) {
+	if s.searcher == nil {
+		return nil, status.Error(codes.Unimplemented, "search is not enabled") //nolint:wrapcheck // Status errors are returned as is.
+	}
+
+	pageSize := int(in.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	offset := 0
+	if in.PageToken != "" {
+		var err error
+		if offset, err = decodeOffsetToken(in.PageToken); err != nil {
+			return nil, invalidField("page_token", err)
+		}
+	}
+
+	hits, total := s.searcher.Search(in.Query, offset, pageSize)
+
+	res := &newsv1.SearchNewsResponse{
+		Results:   make([]*newsv1.SearchResult, 0, len(hits)),
+		TotalSize: int32(total), //nolint:gosec // Bounded by the number of news in memory.
+	}
+	privileged := s.privileged(ctx)
+	for _, hit := range hits {
+		// As the listing, pages may come out short of the news the caller may
+		// not read.
+		if !readable(hit.News, privileged) {
+			continue
+		}
+		result := &newsv1.SearchResult{
+			News:     toNews(hit.News),
+			Score:    hit.Score,
+			Snippets: make([]*newsv1.Snippet, 0, len(hit.Snippets)),
+		}
+		for _, snippet := range hit.Snippets {
+			result.Snippets = append(result.Snippets, &newsv1.Snippet{Field: snippet.Field, Text: snippet.Text})
+		}
+		res.Results = append(res.Results, result)
+	}
+
+	if next := offset + len(hits); next < total {
+		res.NextPageToken = encodeOffsetToken(next)
+	}
+
+	return res, nil
+}
+
+func encodeOffsetToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeOffsetToken(token string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid page token: %w", err)
+	}
+
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid page token: %q", raw)
+	}
+
+	return offset, nil
+}
@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"strings"
+	"time"
 
 	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
 	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/codeandlearn1991/news-grpc/internal/search"
+	"github.com/codeandlearn1991/news-grpc/internal/watch"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -16,66 +20,263 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-// NewsStorer to store news.
+// NewsStorer to store news. Implementations report failures with the errors
+// of the memstore package, such as memstore.ErrNotFound, wrapped as needed.
 type NewsStorer interface {
-	Create(news *memstore.News) *memstore.News
-	Get(id uuid.UUID) *memstore.News
-	GetAll() []*memstore.News
-	Update(news *memstore.News)
-	Delete(id uuid.UUID)
+	NewsReader
+	NewsWriter
+	RevisionStorer
+	TrashStorer
+}
+
+// NewsReader to read the stored news.
+type NewsReader interface {
+	Get(ctx context.Context, id uuid.UUID) (*memstore.News, error)
+	GetMany(ctx context.Context, ids []uuid.UUID) ([]*memstore.News, error)
+	GetAll(ctx context.Context) ([]*memstore.News, error)
+	List(ctx context.Context, query *memstore.Query) ([]*memstore.News, error)
+	Changes(ctx context.Context, after int64, limit int) ([]*memstore.News, error)
+}
+
+// NewsWriter to write the news.
+type NewsWriter interface {
+	Create(ctx context.Context, news *memstore.News) (*memstore.News, error)
+	Update(ctx context.Context, news *memstore.News) (*memstore.News, error)
+	UpdateAll(ctx context.Context, updates []*memstore.News) ([]*memstore.News, error)
+	Delete(ctx context.Context, id uuid.UUID, version int64) (*memstore.News, error)
+	Transition(ctx context.Context, id uuid.UUID, transition memstore.Transition, version int64) (*memstore.News, error)
+}
+
+// RevisionStorer to read the revisions of the news and roll them back.
+type RevisionStorer interface {
+	Revisions(ctx context.Context, id uuid.UUID) ([]*memstore.Revision, error)
+	Revision(ctx context.Context, id uuid.UUID, number int64) (*memstore.Revision, error)
+	Rollback(ctx context.Context, id uuid.UUID, number, version int64) (*memstore.News, error)
+}
+
+// TrashStorer to manage the soft deleted news.
+type TrashStorer interface {
+	Trash(ctx context.Context, after int64, limit int) ([]*memstore.News, error)
+	Restore(ctx context.Context, id uuid.UUID, version int64) (*memstore.News, error)
+	Purge(ctx context.Context, ids ...uuid.UUID) ([]*memstore.News, error)
+	PurgeDeleted(ctx context.Context, before time.Time) ([]*memstore.News, error)
+}
+
+// Searcher to search news by their content.
+type Searcher interface {
+	Search(query string, offset, limit int) ([]search.Hit, int)
+}
+
+// Watcher to subscribe to the changes of the news.
+type Watcher interface {
+	Subscribe(filter watch.Filter, since int64, policy watch.Policy) (*watch.Subscription, error)
+}
+
+// Option to configure the server.
+type Option func(*Server)
+
+// WithSearcher enables the SearchNews RPC backed by the searcher.
+func WithSearcher(searcher Searcher) Option {
+	return func(s *Server) {
+		s.searcher = searcher
+	}
+}
+
+// WithWatcher enables the WatchNews RPC backed by the watcher.
+func WithWatcher(watcher Watcher) Option {
+	return func(s *Server) {
+		s.watcher = watcher
+	}
+}
+
+// WithPrivileged sets how the callers allowed to list the unpublished news
+// and to read the news under embargo are told apart, no caller is by default.
+func WithPrivileged(privileged func(ctx context.Context) bool) Option {
+	return func(s *Server) {
+		s.privileged = privileged
+	}
+}
+
+// WithEditor sets how the callers are named as the last editor of the news
+// they write, they are unknown by default.
+func WithEditor(editor func(ctx context.Context) string) Option {
+	return func(s *Server) {
+		s.editor = editor
+	}
 }
 
 // Server implements of NewServiceServer.
 type Server struct {
 	newsv1.UnimplementedNewsServiceServer
-	store NewsStorer
+	store      NewsStorer
+	searcher   Searcher
+	watcher    Watcher
+	privileged func(ctx context.Context) bool
+	editor     func(ctx context.Context) string
 }
 
 // NewServer returns an intialized instance of Server.
-func NewServer(store NewsStorer) *Server {
-	return &Server{
-		store: store,
+func NewServer(store NewsStorer, opts ...Option) *Server {
+	s := &Server{
+		store:      store,
+		privileged: func(context.Context) bool { return false },
+		editor:     func(context.Context) string { return "" },
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // Create method implementation for the news gRPC server.
-func (s *Server) Create(_ context.Context, in *newsv1.CreateRequest) (*newsv1.CreateResponse, error) {
-	parsedNews, err := parseAndValidate(in)
+func (s *Server) Create(ctx context.Context, in *newsv1.CreateRequest) (*newsv1.CreateResponse, error) {
+	parsedNews, violations := parseAndValidate(in)
+	if len(violations) > 0 {
+		return nil, invalidArgument(violations)
+	}
+	createdNews, err := s.store.Create(s.editing(ctx), parsedNews)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, toStatus(err)
 	}
-	createdNews := s.store.Create(parsedNews)
 	return toNewsResponse(createdNews), nil
 }
 
 // Get method implementation for the news gRPC server.
-func (s *Server) Get(_ context.Context, in *newsv1.GetRequest) (*newsv1.GetResponse, error) {
+func (s *Server) Get(ctx context.Context, in *newsv1.GetRequest) (*newsv1.GetResponse, error) {
 	newsUUID, err := uuid.Parse(in.Id)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, invalidField("id", err)
 	}
 
-	fetchedNews := s.store.Get(newsUUID)
-	if fetchedNews == nil {
-		return nil, status.Error(codes.NotFound, "news with given not found")
+	fetchedNews, err := s.store.Get(ctx, newsUUID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if !readable(fetchedNews, s.privileged(ctx)) {
+		return nil, toStatus(fmt.Errorf("%w: %s", memstore.ErrNotFound, newsUUID))
 	}
 
 	return &newsv1.GetResponse{
-		Id:        fetchedNews.ID.String(),
-		Author:    fetchedNews.Author,
-		Title:     fetchedNews.Title,
-		Summary:   fetchedNews.Summary,
-		Content:   fetchedNews.Content,
-		Source:    fetchedNews.Source.String(),
-		Tags:      fetchedNews.Tags,
-		CreatedAt: timestamppb.New(fetchedNews.CreatedAt.UTC()),
-		UpdatedAt: timestamppb.New(fetchedNews.UpdatedAt.UTC()),
+		Id:           fetchedNews.ID.String(),
+		Author:       fetchedNews.Author,
+		Title:        fetchedNews.Title,
+		Summary:      fetchedNews.Summary,
+		Content:      fetchedNews.Content,
+		Source:       fetchedNews.Source.String(),
+		Tags:         fetchedNews.Tags,
+		CreatedAt:    timestamppb.New(fetchedNews.CreatedAt.UTC()),
+		UpdatedAt:    timestamppb.New(fetchedNews.UpdatedAt.UTC()),
+		Version:      fetchedNews.Version,
+		State:        toState(fetchedNews.State),
+		PublishAt:    toTimestamp(fetchedNews.PublishAt),
+		EmbargoUntil: toTimestamp(fetchedNews.EmbargoUntil),
+		LastEditor:   fetchedNews.Editor,
 	}, nil
 }
 
-// GetAll news.
+// editing returns the context of the writes of the caller, naming them as the
+// editor of the written news.
+//
+//nolint:ireturn // The context is derived.
+func (s *Server) editing(ctx context.Context) context.Context {
+	return memstore.WithEditor(ctx, s.editor(ctx))
+}
+
+// readable reports whether the caller may read the news. The privileged
+// callers read every news, the others the published news that are neither
+// deleted nor under embargo. Every RPC returning news goes through it.
+func readable(news *memstore.News, privileged bool) bool {
+	return privileged || news.State == memstore.StatePublished && news.DeletedAt.IsZero() && !news.Embargoed(time.Now())
+}
+
+// undeleted returns the news as it was before its deletion, so that only the
+// deletion of the news the caller could read is told to it.
+func undeleted(news *memstore.News) *memstore.News {
+	if news.DeletedAt.IsZero() {
+		return news
+	}
+	restored := *news
+	restored.DeletedAt = time.Time{}
+	return &restored
+}
+
+// toVisibleNews returns the news as the caller may see it in the changes of
+// the news. The news the caller may not read are reduced to their id, state,
+// version and timestamps.
+func toVisibleNews(news *memstore.News, privileged bool) *newsv1.News {
+	if readable(news, privileged) {
+		return toNews(news)
+	}
+	res := &newsv1.News{
+		Id:        news.ID.String(),
+		CreatedAt: timestamppb.New(news.CreatedAt.UTC()),
+		UpdatedAt: timestamppb.New(news.UpdatedAt.UTC()),
+		Version:   news.Version,
+		State:     toState(news.State),
+	}
+	if !news.DeletedAt.IsZero() {
+		res.DeletedAt = timestamppb.New(news.DeletedAt.UTC())
+	}
+	return res
+}
+
+// BatchGetNews returns the news of the ids in the order of the request. Ids
+// that are invalid, unknown or deleted are reported in their result, the news
+// the caller may not read being unknown to it.
+func (s *Server) BatchGetNews(ctx context.Context, in *newsv1.BatchGetNewsRequest) (*newsv1.BatchGetNewsResponse, error) {
+	res := &newsv1.BatchGetNewsResponse{Results: make([]*newsv1.BatchGetNewsResult, len(in.Ids))}
+	ids := make([]uuid.UUID, 0, len(in.Ids))
+	// lookups holds the index of the result of each parsed id.
+	lookups := make([]int, 0, len(in.Ids))
+	for i, rawID := range in.Ids {
+		res.Results[i] = &newsv1.BatchGetNewsResult{Id: rawID}
+		id, err := uuid.Parse(rawID)
+		if err != nil {
+			res.Results[i].Outcome = newsv1.BatchGetNewsResult_OUTCOME_INVALID
+			res.Results[i].Message = err.Error()
+			continue
+		}
+		ids = append(ids, id)
+		lookups = append(lookups, i)
+	}
+
+	fetchedNews, err := s.store.GetMany(ctx, ids)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	privileged := s.privileged(ctx)
+	for i, news := range fetchedNews {
+		result := res.Results[lookups[i]]
+		switch {
+		case news == nil, !readable(undeleted(news), privileged):
+			result.Outcome = newsv1.BatchGetNewsResult_OUTCOME_NOT_FOUND
+			result.Message = fmt.Sprintf("%v: %s", memstore.ErrNotFound, ids[i])
+		case !news.DeletedAt.IsZero():
+			result.Outcome = newsv1.BatchGetNewsResult_OUTCOME_DELETED
+			result.DeletedAt = timestamppb.New(news.DeletedAt.UTC())
+		default:
+			result.Outcome = newsv1.BatchGetNewsResult_OUTCOME_FOUND
+			result.News = toNews(news)
+		}
+	}
+
+	return res, nil
+}
+
+// GetAll published news, along with the news scheduled or under embargo for
+// the privileged callers.
 func (s *Server) GetAll(_ *emptypb.Empty, stream newsv1.NewsService_GetAllServer) error {
-	for _, fetchedNews := range s.store.GetAll() {
+	allNews, err := s.store.GetAll(stream.Context())
+	if err != nil {
+		return toStatus(err)
+	}
+
+	privileged := s.privileged(stream.Context())
+	for _, fetchedNews := range allNews {
+		released := fetchedNews.State == memstore.StatePublished || fetchedNews.State == memstore.StateScheduled
+		if !released || !readable(fetchedNews, privileged) {
+			continue
+		}
 		if err := stream.Send(&newsv1.GetAllResponse{
 			Id:        fetchedNews.ID.String(),
 			Author:    fetchedNews.Author,
@@ -86,6 +287,7 @@ func (s *Server) GetAll(_ *emptypb.Empty, stream newsv1.NewsService_GetAllServer
 			Tags:      fetchedNews.Tags,
 			CreatedAt: timestamppb.New(fetchedNews.CreatedAt.UTC()),
 			UpdatedAt: timestamppb.New(fetchedNews.UpdatedAt.UTC()),
+			Version:   fetchedNews.Version,
 		}); err != nil {
 			return err
 		}
@@ -93,25 +295,9 @@ func (s *Server) GetAll(_ *emptypb.Empty, stream newsv1.NewsService_GetAllServer
 	return nil
 }
 
-// UpdateNews gRPC method.
-func (s *Server) UpdateNews(stream newsv1.NewsService_UpdateNewsServer) error {
-	for {
-		req, err := stream.Recv()
-		if errors.Is(err, io.EOF) {
-			return stream.SendAndClose(&emptypb.Empty{})
-		}
-		if err != nil {
-			return err
-		}
-		updatedNews, err := parseAndValidate(req)
-		if err != nil {
-			return status.Errorf(codes.InvalidArgument, "validation failed %v", err)
-		}
-		s.store.Update(updatedNews)
-	}
-}
-
-// DeletedNews from store.
+// DeletedNews from store, acknowledging every id with the outcome of its
+// deletion. Failures specific to an id are reported in its acknowledgement
+// and the stream goes on.
 func (s *Server) DeletedNews(stream newsv1.NewsService_DeletedNewsServer) error {
 	for {
 		req, err := stream.Recv()
@@ -123,69 +309,132 @@ func (s *Server) DeletedNews(stream newsv1.NewsService_DeletedNewsServer) error
 			return err
 		}
 
+		res := &newsv1.DeletedNewsResponse{Id: req.Id}
+		// The items of the stream are not validated by the interceptors, so
+		// that an invalid item only fails itself.
+		violations := validateMessage(req, "")
 		newsUUID, err := uuid.Parse(req.Id)
-		if err != nil {
-			return status.Error(codes.InvalidArgument, err.Error())
+		switch {
+		case len(violations) > 0:
+			res.Outcome = newsv1.DeletedNewsResponse_OUTCOME_INVALID
+			res.Message = describe(violations)
+		case err != nil:
+			res.Outcome = newsv1.DeletedNewsResponse_OUTCOME_INVALID
+			res.Message = err.Error()
+		default:
+			deletedNews, err := s.store.Delete(s.editing(stream.Context()), newsUUID, req.Version)
+			switch {
+			case err == nil:
+				res.Outcome = newsv1.DeletedNewsResponse_OUTCOME_DELETED
+			case errors.Is(err, memstore.ErrAlreadyDeleted):
+				res.Outcome = newsv1.DeletedNewsResponse_OUTCOME_ALREADY_DELETED
+				res.Message = err.Error()
+			case errors.Is(err, memstore.ErrNotFound):
+				res.Outcome = newsv1.DeletedNewsResponse_OUTCOME_NOT_FOUND
+				res.Message = err.Error()
+			case errors.Is(err, memstore.ErrConflict):
+				res.Outcome = newsv1.DeletedNewsResponse_OUTCOME_CONFLICT
+				res.Message = err.Error()
+			default:
+				return toStatus(err)
+			}
+			if deletedNews != nil {
+				res.DeletedAt = timestamppb.New(deletedNews.DeletedAt.UTC())
+			}
 		}
 
-		s.store.Delete(newsUUID)
-		if err := stream.Send(&emptypb.Empty{}); err != nil {
+		if err := stream.Send(res); err != nil {
 			return err
 		}
 	}
 }
 
-func parseAndValidate(in *newsv1.CreateRequest) (n *memstore.News, errs error) {
+// toStatus maps the errors of the store to gRPC status errors.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, memstore.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, memstore.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, memstore.ErrConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, memstore.ErrExpired):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, memstore.ErrUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// describe the violations in a message.
+func describe(violations []*newsv1.FieldViolation) string {
+	descriptions := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		descriptions = append(descriptions, violation.GetField()+": "+violation.GetDescription())
+	}
+	return strings.Join(descriptions, "; ")
+}
+
+// parseAndValidate the request into a news, or returns the violations of its
+// fields.
+func parseAndValidate(in *newsv1.CreateRequest) (*memstore.News, []*newsv1.FieldViolation) {
 	if in == nil {
-		return nil, errors.New("news request empty")
+		return nil, []*newsv1.FieldViolation{{Description: "news request empty"}}
+	}
+
+	var violations []*newsv1.FieldViolation
+	violate := func(field, description string) {
+		violations = append(violations, &newsv1.FieldViolation{Field: field, Description: description})
 	}
 
 	if in.Author == "" {
-		errs = errors.Join(errs, errors.New("author cannot be empty"))
+		violate("author", "author cannot be empty")
 	}
 
 	if in.Title == "" {
-		errs = errors.Join(errs, errors.New("title cannot be empty"))
+		violate("title", "title cannot be empty")
 	}
 
 	if in.Summary == "" {
-		errs = errors.Join(errs, errors.New("summary cannot be empty"))
+		violate("summary", "summary cannot be empty")
 	}
 
 	if in.Content == "" {
-		errs = errors.Join(errs, errors.New("content cannot be empty"))
-	}
-
-	if in.Tags == nil {
-		errs = errors.Join(errs, errors.New("tags cannot be nil"))
+		violate("content", "content cannot be empty")
 	}
 
 	if len(in.Tags) == 0 {
-		errs = errors.Join(errs, errors.New("tags cannot be empty"))
+		violate("tags", "tags cannot be empty")
 	}
 
 	parsedID, err := uuid.Parse(in.Id)
 	if err != nil {
-		errs = errors.Join(errs, fmt.Errorf("invalid id: %w", err))
+		violate("id", fmt.Sprintf("invalid id: %v", err))
 	}
 
 	parsedURL, err := url.Parse(in.Source)
 	if err != nil {
-		errs = errors.Join(errs, fmt.Errorf("invalid url: %w", err))
+		violate("source", fmt.Sprintf("invalid url: %v", err))
 	}
 
-	if errs != nil {
-		return nil, errs
+	if len(violations) > 0 {
+		return nil, violations
 	}
 
 	return &memstore.News{
-		ID:      parsedID,
-		Author:  in.Author,
-		Title:   in.Title,
-		Summary: in.Summary,
-		Content: in.Content,
-		Source:  parsedURL,
-		Tags:    in.Tags,
+		ID:           parsedID,
+		Author:       in.Author,
+		Title:        in.Title,
+		Summary:      in.Summary,
+		Content:      in.Content,
+		Source:       parsedURL,
+		Tags:         in.Tags,
+		Version:      in.Version,
+		PublishAt:    toTime(in.PublishAt),
+		EmbargoUntil: toTime(in.EmbargoUntil),
 	}, nil
 }
 
@@ -200,5 +449,37 @@ func toNewsResponse(news *memstore.News) *newsv1.CreateResponse {
 		Tags:      news.Tags,
 		CreatedAt: timestamppb.New(news.CreatedAt.UTC()),
 		UpdatedAt: timestamppb.New(news.UpdatedAt.UTC()),
+		Version:   news.Version,
+	}
+}
+
+func toNews(news *memstore.News) *newsv1.News {
+	res := &newsv1.News{
+		Id:           news.ID.String(),
+		Author:       news.Author,
+		Title:        news.Title,
+		Summary:      news.Summary,
+		Content:      news.Content,
+		Source:       news.Source.String(),
+		Tags:         news.Tags,
+		CreatedAt:    timestamppb.New(news.CreatedAt.UTC()),
+		UpdatedAt:    timestamppb.New(news.UpdatedAt.UTC()),
+		Version:      news.Version,
+		State:        toState(news.State),
+		PublishAt:    toTimestamp(news.PublishAt),
+		EmbargoUntil: toTimestamp(news.EmbargoUntil),
+		LastEditor:   news.Editor,
+	}
+	if !news.DeletedAt.IsZero() {
+		res.DeletedAt = timestamppb.New(news.DeletedAt.UTC())
+	}
+	return res
+}
+
+// toTimestamp converts the time, leaving the timestamp unset when it is zero.
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
 	}
+	return timestamppb.New(t.UTC())
 }
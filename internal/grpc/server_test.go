@@ -0,0 +1,305 @@
+package grpc_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	ingrpc "github.com/codeandlearn1991/news-grpc/internal/grpc"
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// getAllStream collects the news sent by GetAll.
+type getAllStream struct {
+	serverStream
+	sent []*newsv1.GetAllResponse
+}
+
+func (s *getAllStream) Send(res *newsv1.GetAllResponse) error {
+	s.sent = append(s.sent, res)
+	return nil
+}
+
+// visibility of a news to a caller, through the RPCs returning news.
+type visibility struct {
+	get   codes.Code
+	batch newsv1.BatchGetNewsResult_Outcome
+	// streamed by GetAll, listed by ListNews with the default states.
+	streamed bool
+	listed   bool
+	// changed tells whether the change of the news carries its content.
+	changed bool
+}
+
+func TestVisibility(t *testing.T) {
+	var (
+		found    = newsv1.BatchGetNewsResult_OUTCOME_FOUND
+		notFound = newsv1.BatchGetNewsResult_OUTCOME_NOT_FOUND
+		deleted  = newsv1.BatchGetNewsResult_OUTCOME_DELETED
+	)
+
+	for _, tc := range []struct {
+		name    string
+		publish bool
+		// schedule the publication, or else embargo the news, for later.
+		schedule   bool
+		embargo    bool
+		delete     bool
+		wantReader visibility
+		wantEditor visibility
+	}{
+		{
+			name:       "published",
+			publish:    true,
+			wantReader: visibility{codes.OK, found, true, true, true},
+			wantEditor: visibility{codes.OK, found, true, true, true},
+		},
+		{
+			name:       "draft",
+			wantReader: visibility{codes.NotFound, notFound, false, false, false},
+			wantEditor: visibility{codes.OK, found, false, false, true},
+		},
+		{
+			name:       "scheduled",
+			publish:    true,
+			schedule:   true,
+			wantReader: visibility{codes.NotFound, notFound, false, false, false},
+			wantEditor: visibility{codes.OK, found, true, false, true},
+		},
+		{
+			name:       "under embargo",
+			publish:    true,
+			embargo:    true,
+			wantReader: visibility{codes.NotFound, notFound, false, false, false},
+			wantEditor: visibility{codes.OK, found, true, false, true},
+		},
+		{
+			name:       "deleted",
+			publish:    true,
+			delete:     true,
+			wantReader: visibility{codes.NotFound, deleted, false, false, false},
+			wantEditor: visibility{codes.NotFound, deleted, false, false, true},
+		},
+		{
+			name:       "deleted under embargo",
+			publish:    true,
+			embargo:    true,
+			delete:     true,
+			wantReader: visibility{codes.NotFound, notFound, false, false, false},
+			wantEditor: visibility{codes.NotFound, deleted, false, false, true},
+		},
+		{
+			name:       "deleted draft",
+			delete:     true,
+			wantReader: visibility{codes.NotFound, notFound, false, false, false},
+			wantEditor: visibility{codes.NotFound, deleted, false, false, true},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			store := memstore.New()
+			news := &memstore.News{Title: "title", Source: &url.URL{Scheme: "https", Host: "example.com"}, Tags: []string{"tag"}}
+			if tc.schedule {
+				news.PublishAt = time.Now().Add(time.Hour)
+			}
+			if tc.embargo {
+				news.EmbargoUntil = time.Now().Add(time.Hour)
+			}
+			news, err := store.Create(ctx, news)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.publish {
+				if _, err = store.Transition(ctx, news.ID, memstore.TransitionPublish, 0); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.delete {
+				if _, err = store.Delete(ctx, news.ID, 0); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for _, role := range []struct {
+				name       string
+				privileged bool
+				want       visibility
+			}{
+				{"reader", false, tc.wantReader},
+				{"editor", true, tc.wantEditor},
+			} {
+				server := ingrpc.NewServer(store, ingrpc.WithPrivileged(func(context.Context) bool { return role.privileged }))
+				got := visibilityOf(ctx, t, server, news.ID.String())
+				if got != role.want {
+					t.Errorf("as %s: visibility = %+v, want %+v", role.name, got, role.want)
+				}
+			}
+		})
+	}
+}
+
+// visibilityOf the news of the id through the RPCs of the server.
+func visibilityOf(ctx context.Context, t *testing.T, server *ingrpc.Server, id string) visibility {
+	t.Helper()
+
+	var got visibility
+	_, err := server.Get(ctx, &newsv1.GetRequest{Id: id})
+	got.get = status.Code(err)
+
+	batch, err := server.BatchGetNews(ctx, &newsv1.BatchGetNewsRequest{Ids: []string{id}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got.batch = batch.GetResults()[0].GetOutcome()
+
+	stream := &getAllStream{serverStream: serverStream{ctx: ctx}}
+	if err = server.GetAll(&emptypb.Empty{}, stream); err != nil { //nolint:contextcheck // The stream carries the context.
+		t.Fatal(err)
+	}
+	list, err := server.ListNews(ctx, &newsv1.ListNewsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got.streamed = len(stream.sent) == 1
+	got.listed = len(list.GetNews()) == 1
+
+	changes, err := server.ListChanges(ctx, &newsv1.ListChangesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got.changed = changes.GetChanges()[0].GetNews().GetTitle() != ""
+	return got
+}
+
+func TestCreateIsIdempotent(t *testing.T) {
+	first := &newsv1.CreateRequest{
+		Id:      uuid.NewString(),
+		Author:  "author",
+		Title:   "a valid title",
+		Summary: "a summary long enough",
+		Content: "some content",
+		Source:  "https://example.com",
+		Tags:    []string{"tag"},
+	}
+	other, ok := proto.Clone(first).(*newsv1.CreateRequest)
+	if !ok {
+		t.Fatalf("Clone() = %T, want %T", other, first)
+	}
+	other.Title = "another title"
+
+	for _, tc := range []struct {
+		name string
+		// restart the store before the replay, when set.
+		restart  restart
+		req      *newsv1.CreateRequest
+		delete   bool
+		wantCode codes.Code
+	}{
+		{name: "same payload", req: first, wantCode: codes.OK},
+		{name: "same payload after a restart", restart: closeAndOpen, req: first, wantCode: codes.OK},
+		{name: "other payload", req: other, wantCode: codes.AlreadyExists},
+		{name: "other payload after a restart", restart: crashAndOpen, req: other, wantCode: codes.AlreadyExists},
+		{name: "same payload of a deleted news", req: first, delete: true, wantCode: codes.AlreadyExists},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			dir := t.TempDir()
+			store := openDiskStore(t, dir)
+			created, err := ingrpc.NewServer(store).Create(ctx, first)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if created.GetId() != first.GetId() {
+				t.Errorf("Create() id = %s, want %s", created.GetId(), first.GetId())
+			}
+			if tc.delete {
+				if _, err = store.Delete(ctx, uuid.MustParse(created.GetId()), 0); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.restart != nil {
+				store = tc.restart(t, store, dir)
+			}
+			defer store.Close()
+
+			replayed, err := ingrpc.NewServer(store).Create(ctx, tc.req)
+			if got := status.Code(err); got != tc.wantCode {
+				t.Fatalf("Create() code = %s, want %s", got, tc.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			// The replay returns the stored news rather than a new version.
+			if replayed.GetVersion() != created.GetVersion() || !replayed.GetCreatedAt().AsTime().Equal(created.GetCreatedAt().AsTime()) {
+				t.Errorf("Create() = version %d created at %v, want version %d created at %v",
+					replayed.GetVersion(), replayed.GetCreatedAt().AsTime(), created.GetVersion(), created.GetCreatedAt().AsTime())
+			}
+			all, err := store.GetAll(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(all) != 1 {
+				t.Errorf("GetAll() = %d news after the replay, want 1", len(all))
+			}
+		})
+	}
+}
+
+func TestBatchGetNews(t *testing.T) {
+	ctx := t.Context()
+	store := memstore.New()
+	live := createNews(ctx, t, store, "live")
+	deleted := createNews(ctx, t, store, "deleted")
+	deleted, err := store.Delete(ctx, deleted.ID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown := uuid.NewString()
+	server := ingrpc.NewServer(store, ingrpc.WithPrivileged(func(context.Context) bool { return true }))
+
+	// The same id may be requested more than once.
+	ids := []string{live.ID.String(), "not a uuid", unknown, deleted.ID.String(), live.ID.String()}
+	res, err := server.BatchGetNews(ctx, &newsv1.BatchGetNewsRequest{Ids: ids})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []newsv1.BatchGetNewsResult_Outcome{
+		newsv1.BatchGetNewsResult_OUTCOME_FOUND,
+		newsv1.BatchGetNewsResult_OUTCOME_INVALID,
+		newsv1.BatchGetNewsResult_OUTCOME_NOT_FOUND,
+		newsv1.BatchGetNewsResult_OUTCOME_DELETED,
+		newsv1.BatchGetNewsResult_OUTCOME_FOUND,
+	}
+	if len(res.GetResults()) != len(want) {
+		t.Fatalf("BatchGetNews() = %d results, want %d", len(res.GetResults()), len(want))
+	}
+	for i, result := range res.GetResults() {
+		if result.GetId() != ids[i] || result.GetOutcome() != want[i] {
+			t.Errorf("result %d = %s of %q, want %s of %q", i, result.GetOutcome(), result.GetId(), want[i], ids[i])
+		}
+		switch result.GetOutcome() {
+		case newsv1.BatchGetNewsResult_OUTCOME_FOUND:
+			if result.GetNews().GetId() != ids[i] {
+				t.Errorf("result %d news = %q, want %q", i, result.GetNews().GetId(), ids[i])
+			}
+		case newsv1.BatchGetNewsResult_OUTCOME_DELETED:
+			if got := result.GetDeletedAt().AsTime(); !got.Equal(deleted.DeletedAt) || result.GetNews() != nil {
+				t.Errorf("result %d deleted at %v with news %v, want deleted at %v without news", i, got, result.GetNews(), deleted.DeletedAt)
+			}
+		case newsv1.BatchGetNewsResult_OUTCOME_UNSPECIFIED,
+			newsv1.BatchGetNewsResult_OUTCOME_NOT_FOUND,
+			newsv1.BatchGetNewsResult_OUTCOME_INVALID:
+			if result.GetMessage() == "" || result.GetNews() != nil {
+				t.Errorf("result %d message = %q with news %v, want a message without news", i, result.GetMessage(), result.GetNews())
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/google/uuid"
+)
+
+// ListTrash returns a page of the soft deleted news in the order of their
+// deletion.
+func (s *Server) ListTrash(ctx context.Context, in *newsv1.ListTrashRequest) (*newsv1.ListTrashResponse, error) {
+	pageSize := int(in.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	var after int64
+	if in.PageToken != "" {
+		var err error
+		if after, err = decodeChangeCursor(in.PageToken); err != nil {
+			return nil, invalidField("page_token", err)
+		}
+	}
+
+	// One more than the page size tells whether a next page exists.
+	deletedNews, err := s.store.Trash(ctx, after, pageSize+1)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &newsv1.ListTrashResponse{}
+	if len(deletedNews) > pageSize {
+		deletedNews = deletedNews[:pageSize]
+		res.NextPageToken = encodeChangeCursor(deletedNews[pageSize-1].Seq)
+	}
+	res.News = make([]*newsv1.News, 0, len(deletedNews))
+	for _, news := range deletedNews {
+		res.News = append(res.News, toNews(news))
+	}
+
+	return res, nil
+}
+
+// RestoreNews clears the deletion of a soft deleted news.
+func (s *Server) RestoreNews(ctx context.Context, in *newsv1.RestoreNewsRequest) (*newsv1.News, error) {
+	id, err := uuid.Parse(in.Id)
+	if err != nil {
+		return nil, invalidField("id", err)
+	}
+	restoredNews, err := s.store.Restore(s.editing(ctx), id, in.Version)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toNews(restoredNews), nil
+}
+
+// PurgeNews permanently deletes soft deleted news, all of them or none.
+func (s *Server) PurgeNews(ctx context.Context, in *newsv1.PurgeNewsRequest) (*newsv1.PurgeNewsResponse, error) {
+	ids := make([]uuid.UUID, 0, len(in.Ids))
+	var violations []*newsv1.FieldViolation
+	for i, rawID := range in.Ids {
+		id, err := uuid.Parse(rawID)
+		if err != nil {
+			violations = append(violations, &newsv1.FieldViolation{Field: fmt.Sprintf("ids[%d]", i), Description: err.Error()})
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if len(violations) > 0 {
+		return nil, invalidArgument(violations)
+	}
+	purged, err := s.store.Purge(ctx, ids...)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &newsv1.PurgeNewsResponse{PurgedCount: int32(len(purged))}, nil //nolint:gosec // Bounded by the request.
+}
+
+// EmptyTrash permanently deletes the news soft deleted before the timestamp
+// of the request, or all of them when unset.
+func (s *Server) EmptyTrash(ctx context.Context, in *newsv1.EmptyTrashRequest) (*newsv1.EmptyTrashResponse, error) {
+	before := time.Now()
+	if in.DeletedBefore != nil {
+		before = in.DeletedBefore.AsTime()
+	}
+	purged, err := s.store.PurgeDeleted(ctx, before)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &newsv1.EmptyTrashResponse{PurgedCount: int32(len(purged))}, nil //nolint:gosec // Fits unless the trash holds billions of news.
+}
@@ -0,0 +1,354 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/codeandlearn1991/news-grpc/internal/validation"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// updatableFields of the news, in the order of the News message.
+var updatableFields = []string{
+	"author", "title", "summary", "content", "source", "tags", "publish_at", "embargo_until",
+}
+
+// maxUpdateAttempts bounds the retries of an unversioned update racing with
+// other writes.
+const maxUpdateAttempts = 3
+
+// patch is a parsed and validated update of a news.
+type patch struct {
+	id      uuid.UUID
+	news    *newsv1.News
+	source  *url.URL
+	paths   []string
+	version int64
+}
+
+// pendingUpdate of an all-or-nothing stream, along with its result.
+type pendingUpdate struct {
+	patch  *patch
+	result *newsv1.UpdateNewsResult
+}
+
+// Update the fields of the update mask of the news.
+func (s *Server) Update(ctx context.Context, in *newsv1.UpdateNewsRequest) (*newsv1.News, error) {
+	p, violations := parsePatch(in)
+	if len(violations) > 0 {
+		return nil, invalidArgument(violations)
+	}
+	updatedNews, err := s.applyPatch(ctx, p)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toNews(updatedNews), nil
+}
+
+// UpdateNews applies the streamed updates and reports the outcome of each
+// one. In UPDATE_MODE_ALL_OR_NOTHING the updates are applied once the stream
+// is closed and only when none of them fails.
+func (s *Server) UpdateNews(stream newsv1.NewsService_UpdateNewsServer) error {
+	ctx := stream.Context()
+	res := &newsv1.UpdateNewsResponse{}
+	mode := newsv1.UpdateMode_UPDATE_MODE_BEST_EFFORT
+	var pending []pendingUpdate
+
+	for index := int32(0); ; index++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err //nolint:wrapcheck // The stream errors are status errors.
+		}
+		if index == 0 && req.Mode != newsv1.UpdateMode_UPDATE_MODE_UNSPECIFIED {
+			mode = req.Mode
+		}
+
+		result := &newsv1.UpdateNewsResult{Index: index, Id: req.GetNews().GetId()}
+		res.Results = append(res.Results, result)
+
+		p, violations := parsePatch(req)
+		if len(violations) > 0 {
+			result.Outcome = newsv1.UpdateNewsResult_OUTCOME_INVALID
+			result.Violations = violations
+			result.Message = "validation failed"
+			continue
+		}
+		if mode == newsv1.UpdateMode_UPDATE_MODE_ALL_OR_NOTHING {
+			pending = append(pending, pendingUpdate{patch: p, result: result})
+			continue
+		}
+		updatedNews, err := s.applyPatch(ctx, p)
+		if statusErr := setOutcome(result, updatedNews, err); statusErr != nil {
+			return statusErr
+		}
+	}
+
+	if mode == newsv1.UpdateMode_UPDATE_MODE_ALL_OR_NOTHING {
+		if err := s.applyAll(ctx, res.Results, pending); err != nil {
+			return err
+		}
+	}
+	return stream.SendAndClose(res) //nolint:wrapcheck // The stream errors are status errors.
+}
+
+// applyPatch to the stored news. An unversioned patch is checked against the
+// version it was merged with, so that it never overwrites a concurrent write
+// of other fields, and is merged again when it loses the race.
+func (s *Server) applyPatch(ctx context.Context, p *patch) (*memstore.News, error) {
+	for attempt := 1; ; attempt++ {
+		storedNews, err := s.store.Get(ctx, p.id)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // The store errors are mapped by the callers.
+		}
+		updatedNews, err := s.store.Update(s.editing(ctx), p.applyTo(storedNews))
+		if errors.Is(err, memstore.ErrConflict) && p.version == 0 && attempt < maxUpdateAttempts {
+			continue
+		}
+		return updatedNews, err //nolint:wrapcheck // The store errors are mapped by the callers.
+	}
+}
+
+// applyAll applies the pending updates at once when no update of the stream
+// failed, and records their outcome in the results.
+func (s *Server) applyAll(ctx context.Context, results []*newsv1.UpdateNewsResult, pending []pendingUpdate) error {
+	failed := slices.ContainsFunc(results, func(result *newsv1.UpdateNewsResult) bool {
+		return result.Outcome != newsv1.UpdateNewsResult_OUTCOME_UNSPECIFIED
+	})
+
+	// merged holds the news as left by the previous updates of the stream,
+	// at the version they will be stored at.
+	merged := make(map[uuid.UUID]*memstore.News)
+	updates := make([]*memstore.News, 0, len(pending))
+	queued := make([]pendingUpdate, 0, len(pending))
+	for _, update := range pending {
+		base, ok := merged[update.patch.id]
+		if !ok {
+			var err error
+			if base, err = s.store.Get(ctx, update.patch.id); err != nil {
+				if statusErr := setOutcome(update.result, nil, err); statusErr != nil {
+					return statusErr
+				}
+				failed = true
+				continue
+			}
+		}
+		updatedNews := update.patch.applyTo(base)
+		next := *updatedNews
+		next.Version = updatedNews.Version + 1
+		merged[update.patch.id] = &next
+		updates = append(updates, updatedNews)
+		queued = append(queued, update)
+	}
+
+	var storedNews []*memstore.News
+	if !failed {
+		var err error
+		storedNews, err = s.store.UpdateAll(s.editing(ctx), updates)
+		var batchErr *memstore.BatchError
+		switch {
+		case errors.As(err, &batchErr):
+			for i, update := range queued {
+				if statusErr := setOutcome(update.result, nil, batchErr.Errs[i]); statusErr != nil {
+					return statusErr
+				}
+			}
+		case err != nil:
+			return toStatus(err)
+		}
+	}
+
+	for i, update := range queued {
+		switch {
+		case storedNews != nil:
+			update.result.Outcome = newsv1.UpdateNewsResult_OUTCOME_UPDATED
+			update.result.News = toNews(storedNews[i])
+		case update.result.Outcome == newsv1.UpdateNewsResult_OUTCOME_UNSPECIFIED:
+			update.result.Outcome = newsv1.UpdateNewsResult_OUTCOME_ABORTED
+			update.result.Message = "not applied, another update of the stream failed"
+		}
+	}
+	return nil
+}
+
+// setOutcome records the outcome of an update in its result. Failures that
+// are not specific to the update are returned as status errors.
+func setOutcome(result *newsv1.UpdateNewsResult, updatedNews *memstore.News, err error) error {
+	switch {
+	case err == nil:
+		if updatedNews != nil {
+			result.Outcome = newsv1.UpdateNewsResult_OUTCOME_UPDATED
+			result.News = toNews(updatedNews)
+		}
+	case errors.Is(err, memstore.ErrNotFound):
+		result.Outcome = newsv1.UpdateNewsResult_OUTCOME_NOT_FOUND
+		result.Message = err.Error()
+	case errors.Is(err, memstore.ErrConflict):
+		result.Outcome = newsv1.UpdateNewsResult_OUTCOME_CONFLICT
+		result.Message = err.Error()
+	default:
+		return toStatus(err)
+	}
+	return nil
+}
+
+// parsePatch parses and validates the update, the items of the UpdateNews
+// stream are not validated by the interceptors so that an invalid item only
+// fails itself. Only the masked fields of the news are validated, with the
+// rules of CreateRequest.
+func parsePatch(in *newsv1.UpdateNewsRequest) (*patch, []*newsv1.FieldViolation) {
+	violations := validateMessage(in, "")
+	news := in.GetNews()
+	if news == nil {
+		return nil, violations
+	}
+
+	paths, err := updatePaths(in.GetUpdateMask())
+	if err != nil {
+		violations = append(violations, &newsv1.FieldViolation{Field: "update_mask", Description: err.Error()})
+	}
+	id, err := uuid.Parse(news.GetId())
+	if err != nil {
+		violations = append(violations, &newsv1.FieldViolation{Field: "news.id", Description: err.Error()})
+	}
+	if paths != nil {
+		violations = append(violations, validatePaths(news, paths)...)
+	}
+	source, err := url.Parse(news.GetSource())
+	if err != nil && slices.Contains(paths, "source") {
+		violations = append(violations, &newsv1.FieldViolation{Field: "news.source", Description: err.Error()})
+	}
+	if len(violations) > 0 {
+		return nil, violations
+	}
+
+	return &patch{
+		id:      id,
+		news:    news,
+		source:  source,
+		paths:   paths,
+		version: in.GetVersion(),
+	}, nil
+}
+
+// updatePaths returns the fields of the mask, all the updatable fields when it
+// is empty.
+func updatePaths(mask *fieldmaskpb.FieldMask) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return updatableFields, nil
+	}
+	// The request is shared with the interceptors, the mask is normalized on a
+	// copy.
+	mask = &fieldmaskpb.FieldMask{Paths: slices.Clone(mask.GetPaths())}
+	mask.Normalize()
+	for _, path := range mask.GetPaths() {
+		if !slices.Contains(updatableFields, path) {
+			return nil, fmt.Errorf("field %q cannot be updated", path)
+		}
+	}
+	return mask.GetPaths(), nil
+}
+
+// validatePaths validates the fields of the news in paths against the rules
+// of CreateRequest.
+func validatePaths(news *newsv1.News, paths []string) []*newsv1.FieldViolation {
+	req := &newsv1.CreateRequest{
+		Author:       news.GetAuthor(),
+		Title:        news.GetTitle(),
+		Summary:      news.GetSummary(),
+		Content:      news.GetContent(),
+		Source:       news.GetSource(),
+		Tags:         news.GetTags(),
+		PublishAt:    news.GetPublishAt(),
+		EmbargoUntil: news.GetEmbargoUntil(),
+	}
+	return validateMessage(req, "news.", protovalidate.WithFilter(
+		protovalidate.FilterFunc(func(_ protoreflect.Message, desc protoreflect.Descriptor) bool {
+			field, ok := desc.(protoreflect.FieldDescriptor)
+			return !ok || slices.Contains(paths, string(field.Name()))
+		}),
+	))
+}
+
+// validateMessage validates the message against its rules and returns the
+// violations of its fields, prefixed with the prefix.
+func validateMessage(m proto.Message, prefix string, opts ...protovalidate.ValidationOption) []*newsv1.FieldViolation {
+	err := protovalidate.Validate(m, opts...)
+	var validationErr *protovalidate.ValidationError
+	if !errors.As(err, &validationErr) {
+		if err != nil {
+			return []*newsv1.FieldViolation{{Field: strings.TrimSuffix(prefix, "."), Description: err.Error()}}
+		}
+		return nil
+	}
+	fieldViolations := validation.Violations(prefix, validationErr)
+	violations := make([]*newsv1.FieldViolation, 0, len(fieldViolations))
+	for _, violation := range fieldViolations {
+		violations = append(violations, &newsv1.FieldViolation{Field: violation.Field, Description: violation.Description})
+	}
+	return violations
+}
+
+// invalidArgument returns the violations as an InvalidArgument status error
+// with a google.rpc.BadRequest detail.
+func invalidArgument(violations []*newsv1.FieldViolation) error {
+	fieldViolations := make([]*errdetails.BadRequest_FieldViolation, 0, len(violations))
+	for _, violation := range violations {
+		fieldViolations = append(fieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Description,
+		})
+	}
+	return validation.InvalidArgument(fieldViolations) //nolint:wrapcheck // Status errors are returned as is.
+}
+
+// invalidField returns the error of the field of the request as an
+// InvalidArgument status error.
+func invalidField(field string, err error) error {
+	return invalidArgument([]*newsv1.FieldViolation{{Field: field, Description: err.Error()}})
+}
+
+// applyTo returns a copy of the news with the fields of the patch. It expects
+// the version of the patch, or the version of the news when unset.
+func (p *patch) applyTo(news *memstore.News) *memstore.News {
+	updatedNews := *news
+	for _, path := range p.paths {
+		switch path {
+		case "author":
+			updatedNews.Author = p.news.GetAuthor()
+		case "title":
+			updatedNews.Title = p.news.GetTitle()
+		case "summary":
+			updatedNews.Summary = p.news.GetSummary()
+		case "content":
+			updatedNews.Content = p.news.GetContent()
+		case "source":
+			updatedNews.Source = p.source
+		case "tags":
+			updatedNews.Tags = p.news.GetTags()
+		case "publish_at":
+			updatedNews.PublishAt = toTime(p.news.GetPublishAt())
+		case "embargo_until":
+			updatedNews.EmbargoUntil = toTime(p.news.GetEmbargoUntil())
+		}
+	}
+	updatedNews.Version = p.version
+	if updatedNews.Version == 0 {
+		updatedNews.Version = news.Version
+	}
+	return &updatedNews
+}
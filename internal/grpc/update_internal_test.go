@@ -0,0 +1,294 @@
+package grpc
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// validNews returns a news valid in all its fields.
+func validNews(id uuid.UUID) *newsv1.News {
+	return &newsv1.News{
+		Id:      id.String(),
+		Author:  "author",
+		Title:   "a valid title",
+		Summary: "a summary long enough",
+		Content: strings.Repeat("content ", 20),
+		Source:  "https://example.com",
+		Tags:    []string{"tag"},
+	}
+}
+
+func TestParsePatch(t *testing.T) {
+	id := uuid.New()
+	shortTitle := validNews(id)
+	shortTitle.Title = "short"
+
+	for _, tc := range []struct {
+		name           string
+		news           *newsv1.News
+		paths          []string
+		wantPaths      []string
+		wantViolations []string
+	}{
+		{
+			name:      "empty mask",
+			news:      validNews(id),
+			wantPaths: updatableFields,
+		},
+		{
+			name:      "normalized mask",
+			news:      validNews(id),
+			paths:     []string{"title", "author", "title"},
+			wantPaths: []string{"author", "title"},
+		},
+		{
+			name:      "path under a masked field",
+			news:      validNews(id),
+			paths:     []string{"source", "source.host"},
+			wantPaths: []string{"source"},
+		},
+		{
+			name:      "repeated field",
+			news:      validNews(id),
+			paths:     []string{"tags"},
+			wantPaths: []string{"tags"},
+		},
+		{
+			name:           "nested path",
+			news:           validNews(id),
+			paths:          []string{"source.host"},
+			wantViolations: []string{"update_mask"},
+		},
+		{
+			name:           "element of a repeated field",
+			news:           validNews(id),
+			paths:          []string{"tags.0"},
+			wantViolations: []string{"update_mask"},
+		},
+		{
+			name:           "unknown path",
+			news:           validNews(id),
+			paths:          []string{"title", "headline"},
+			wantViolations: []string{"update_mask"},
+		},
+		{
+			name:           "field that is not updatable",
+			news:           validNews(id),
+			paths:          []string{"version"},
+			wantViolations: []string{"update_mask"},
+		},
+		{
+			name:           "invalid masked field",
+			news:           shortTitle,
+			paths:          []string{"title"},
+			wantViolations: []string{"news.title"},
+		},
+		{
+			name:      "invalid field out of the mask",
+			news:      shortTitle,
+			paths:     []string{"author"},
+			wantPaths: []string{"author"},
+		},
+		{
+			name:           "invalid field with an empty mask",
+			news:           shortTitle,
+			wantViolations: []string{"news.title"},
+		},
+		{
+			name:           "invalid id",
+			news:           &newsv1.News{Id: "not a uuid", Author: "author"},
+			paths:          []string{"author"},
+			wantViolations: []string{"news.id"},
+		},
+		{
+			name:           "no news",
+			paths:          []string{"author"},
+			wantViolations: []string{"news"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			in := &newsv1.UpdateNewsRequest{News: tc.news, UpdateMask: &fieldmaskpb.FieldMask{Paths: slices.Clone(tc.paths)}}
+
+			p, violations := parsePatch(in)
+			var gotViolations []string
+			for _, violation := range violations {
+				gotViolations = append(gotViolations, violation.GetField())
+			}
+			if !slices.Equal(gotViolations, tc.wantViolations) {
+				t.Fatalf("parsePatch() violations = %q, want %q", gotViolations, tc.wantViolations)
+			}
+			if len(violations) > 0 {
+				return
+			}
+			if !slices.Equal(p.paths, tc.wantPaths) {
+				t.Errorf("parsePatch() paths = %q, want %q", p.paths, tc.wantPaths)
+			}
+			if p.id != id {
+				t.Errorf("parsePatch() id = %s, want %s", p.id, id)
+			}
+			// The mask of the request is left as is for the interceptors.
+			if got := in.GetUpdateMask().GetPaths(); !slices.Equal(got, tc.paths) {
+				t.Errorf("parsePatch() changed the mask of the request to %q, want %q", got, tc.paths)
+			}
+		})
+	}
+}
+
+func TestPatchApplyTo(t *testing.T) {
+	stored := &memstore.News{
+		ID:           uuid.New(),
+		Author:       "stored author",
+		Title:        "stored title",
+		Summary:      "stored summary",
+		Content:      "stored content",
+		Source:       &url.URL{Scheme: "https", Host: "stored.example.com"},
+		Tags:         []string{"stored"},
+		EmbargoUntil: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		Version:      3,
+	}
+	publishAt := time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)
+	update := &newsv1.News{
+		Author:    "new author",
+		Title:     "new title",
+		Summary:   "new summary",
+		Content:   "new content",
+		Tags:      []string{"new"},
+		PublishAt: timestamppb.New(publishAt),
+	}
+	source := &url.URL{Scheme: "https", Host: "new.example.com"}
+
+	for _, tc := range []struct {
+		name    string
+		paths   []string
+		version int64
+		want    func(news *memstore.News)
+	}{
+		{
+			name:  "masked fields",
+			paths: []string{"title", "tags"},
+			want: func(news *memstore.News) {
+				news.Title = "new title"
+				news.Tags = []string{"new"}
+			},
+		},
+		{
+			name:  "all fields",
+			paths: updatableFields,
+			want: func(news *memstore.News) {
+				news.Author = "new author"
+				news.Title = "new title"
+				news.Summary = "new summary"
+				news.Content = "new content"
+				news.Source = source
+				news.Tags = []string{"new"}
+				news.PublishAt = publishAt
+				news.EmbargoUntil = time.Time{}
+			},
+		},
+		{
+			name:  "cleared embargo",
+			paths: []string{"embargo_until"},
+			want: func(news *memstore.News) {
+				news.EmbargoUntil = time.Time{}
+			},
+		},
+		{
+			name:    "expected version",
+			paths:   []string{"author"},
+			version: 2,
+			want: func(news *memstore.News) {
+				news.Author = "new author"
+				news.Version = 2
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &patch{id: stored.ID, news: update, source: source, paths: tc.paths, version: tc.version}
+			before := *stored
+
+			got := p.applyTo(stored)
+			want := *stored
+			tc.want(&want)
+			if got.Author != want.Author || got.Title != want.Title || got.Summary != want.Summary ||
+				got.Content != want.Content || got.Source.String() != want.Source.String() ||
+				!slices.Equal(got.Tags, want.Tags) || !got.PublishAt.Equal(want.PublishAt) ||
+				!got.EmbargoUntil.Equal(want.EmbargoUntil) || got.Version != want.Version {
+				t.Errorf("applyTo() = %+v, want %+v", got, want)
+			}
+			if stored.Title != before.Title || stored.Version != before.Version {
+				t.Errorf("applyTo() changed the stored news to %+v", stored)
+			}
+		})
+	}
+}
+
+func TestServerApplyPatch(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		id      func(news *memstore.News) uuid.UUID
+		version func(news *memstore.News) int64
+		wantErr error
+	}{
+		{
+			name:    "any version",
+			id:      func(news *memstore.News) uuid.UUID { return news.ID },
+			version: func(*memstore.News) int64 { return 0 },
+		},
+		{
+			name:    "current version",
+			id:      func(news *memstore.News) uuid.UUID { return news.ID },
+			version: func(news *memstore.News) int64 { return news.Version },
+		},
+		{
+			name:    "stale version",
+			id:      func(news *memstore.News) uuid.UUID { return news.ID },
+			version: func(news *memstore.News) int64 { return news.Version - 1 },
+			wantErr: memstore.ErrConflict,
+		},
+		{
+			name:    "unknown news",
+			id:      func(*memstore.News) uuid.UUID { return uuid.New() },
+			version: func(*memstore.News) int64 { return 0 },
+			wantErr: memstore.ErrNotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			store := memstore.New()
+			news, err := store.Create(ctx, &memstore.News{Title: "title"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if news, err = store.Update(ctx, &memstore.News{ID: news.ID, Title: "title", Version: news.Version}); err != nil {
+				t.Fatal(err)
+			}
+			p := &patch{
+				id:      tc.id(news),
+				news:    &newsv1.News{Title: "patched title"},
+				paths:   []string{"title"},
+				version: tc.version(news),
+			}
+
+			got, err := NewServer(store).applyPatch(ctx, p)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("applyPatch() error = %v, want %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Title != "patched title" || got.Version != news.Version+1 {
+				t.Errorf("applyPatch() = %q at version %d, want %q at version %d", got.Title, got.Version, "patched title", news.Version+1)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package grpc_test
+
+import (
+	"context"
+	"io"
+	"slices"
+	"testing"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	ingrpc "github.com/codeandlearn1991/news-grpc/internal/grpc"
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestInvalidArgumentDetails(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		call       func(ctx context.Context, server *ingrpc.Server) *status.Status
+		wantFields []string
+	}{
+		{
+			name: "invalid id",
+			call: func(ctx context.Context, server *ingrpc.Server) *status.Status {
+				_, err := server.Get(ctx, &newsv1.GetRequest{Id: "not a uuid"})
+				return status.Convert(err)
+			},
+			wantFields: []string{"id"},
+		},
+		{
+			name: "invalid page token",
+			call: func(ctx context.Context, server *ingrpc.Server) *status.Status {
+				_, err := server.ListNews(ctx, &newsv1.ListNewsRequest{PageToken: "not a token"})
+				return status.Convert(err)
+			},
+			wantFields: []string{"page_token"},
+		},
+		{
+			name: "empty fields",
+			call: func(ctx context.Context, server *ingrpc.Server) *status.Status {
+				_, err := server.Create(ctx, &newsv1.CreateRequest{Author: "author", Title: "title"})
+				return status.Convert(err)
+			},
+			wantFields: []string{"summary", "content", "tags", "id"},
+		},
+		{
+			name: "field out of the update mask",
+			call: func(ctx context.Context, server *ingrpc.Server) *status.Status {
+				_, err := server.Update(ctx, &newsv1.UpdateNewsRequest{
+					News:       &newsv1.News{Id: "3b241101-e2bb-4255-8caf-4136c566a962"},
+					UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"version"}},
+				})
+				return status.Convert(err)
+			},
+			wantFields: []string{"update_mask"},
+		},
+		{
+			name: "masked fields",
+			call: func(ctx context.Context, server *ingrpc.Server) *status.Status {
+				_, err := server.Update(ctx, &newsv1.UpdateNewsRequest{
+					News:       &newsv1.News{Id: "not a uuid", Title: "short"},
+					UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+				})
+				return status.Convert(err)
+			},
+			wantFields: []string{"news.id", "news.title"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			st := tc.call(t.Context(), ingrpc.NewServer(memstore.New()))
+			if st.Code() != codes.InvalidArgument {
+				t.Fatalf("code = %s, want %s", st.Code(), codes.InvalidArgument)
+			}
+			var gotFields []string
+			for _, detail := range st.Details() {
+				badRequest, ok := detail.(*errdetails.BadRequest)
+				if !ok {
+					t.Fatalf("detail = %T, want %T", detail, badRequest)
+				}
+				for _, violation := range badRequest.GetFieldViolations() {
+					if violation.GetDescription() == "" {
+						t.Errorf("violation of %s has no description", violation.GetField())
+					}
+					gotFields = append(gotFields, violation.GetField())
+				}
+			}
+			if !slices.Equal(gotFields, tc.wantFields) {
+				t.Errorf("violated fields = %q, want %q", gotFields, tc.wantFields)
+			}
+		})
+	}
+}
+
+// updateStream feeds the requests to UpdateNews and keeps its response.
+type updateStream struct {
+	serverStream
+	reqs []*newsv1.UpdateNewsRequest
+	res  *newsv1.UpdateNewsResponse
+}
+
+func (s *updateStream) Recv() (*newsv1.UpdateNewsRequest, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+
+func (s *updateStream) SendAndClose(res *newsv1.UpdateNewsResponse) error {
+	s.res = res
+	return nil
+}
+
+func TestUpdateNewsStream(t *testing.T) {
+	var (
+		updated  = newsv1.UpdateNewsResult_OUTCOME_UPDATED
+		invalid  = newsv1.UpdateNewsResult_OUTCOME_INVALID
+		notFound = newsv1.UpdateNewsResult_OUTCOME_NOT_FOUND
+		aborted  = newsv1.UpdateNewsResult_OUTCOME_ABORTED
+	)
+
+	for _, tc := range []struct {
+		name         string
+		mode         newsv1.UpdateMode
+		wantOutcomes []newsv1.UpdateNewsResult_Outcome
+		wantTitles   []string
+	}{
+		{
+			name:         "best effort",
+			mode:         newsv1.UpdateMode_UPDATE_MODE_BEST_EFFORT,
+			wantOutcomes: []newsv1.UpdateNewsResult_Outcome{updated, invalid, notFound, updated},
+			wantTitles:   []string{"first updated", "second updated"},
+		},
+		{
+			name:         "all or nothing",
+			mode:         newsv1.UpdateMode_UPDATE_MODE_ALL_OR_NOTHING,
+			wantOutcomes: []newsv1.UpdateNewsResult_Outcome{aborted, invalid, notFound, aborted},
+			wantTitles:   []string{"first", "second"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			store := memstore.New()
+			first := createNews(ctx, t, store, "first")
+			second := createNews(ctx, t, store, "second")
+			titleUpdate := func(id, title string) *newsv1.UpdateNewsRequest {
+				return &newsv1.UpdateNewsRequest{
+					News:       &newsv1.News{Id: id, Title: title},
+					UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+				}
+			}
+			stream := &updateStream{
+				serverStream: serverStream{ctx: ctx},
+				reqs: []*newsv1.UpdateNewsRequest{
+					titleUpdate(first.ID.String(), "first updated"),
+					// Too short a title.
+					titleUpdate(second.ID.String(), "short"),
+					titleUpdate(uuid.NewString(), "unknown updated"),
+					titleUpdate(second.ID.String(), "second updated"),
+				},
+			}
+			stream.reqs[0].Mode = tc.mode
+
+			if err := ingrpc.NewServer(store).UpdateNews(stream); err != nil {
+				t.Fatalf("UpdateNews() error = %v", err)
+			}
+			var gotOutcomes []newsv1.UpdateNewsResult_Outcome
+			for i, result := range stream.res.GetResults() {
+				gotOutcomes = append(gotOutcomes, result.GetOutcome())
+				if result.GetIndex() != int32(i) { //nolint:gosec // A handful of results.
+					t.Errorf("result %d index = %d", i, result.GetIndex())
+				}
+			}
+			if !slices.Equal(gotOutcomes, tc.wantOutcomes) {
+				t.Errorf("UpdateNews() outcomes = %v, want %v", gotOutcomes, tc.wantOutcomes)
+			}
+			if violations := stream.res.GetResults()[1].GetViolations(); len(violations) != 1 || violations[0].GetField() != "news.title" {
+				t.Errorf("UpdateNews() violations = %v, want one of news.title", violations)
+			}
+
+			var gotTitles []string
+			for _, news := range []*memstore.News{first, second} {
+				stored, err := store.Get(ctx, news.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				gotTitles = append(gotTitles, stored.Title)
+			}
+			if !slices.Equal(gotTitles, tc.wantTitles) {
+				t.Errorf("stored titles = %q, want %q", gotTitles, tc.wantTitles)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"errors"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/internal/watch"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchNews streams the changes of the news matching the request until the
+// client goes away. The news the caller may not read are redacted.
+func (s *Server) WatchNews(in *newsv1.WatchNewsRequest, stream newsv1.NewsService_WatchNewsServer) error {
+	if s.watcher == nil {
+		return status.Error(codes.Unimplemented, "watch is not enabled") //nolint:wrapcheck // Status errors are returned as is.
+	}
+
+	policy := watch.PolicyBuffer
+	switch in.Policy {
+	case newsv1.SlowConsumerPolicy_SLOW_CONSUMER_POLICY_DROP:
+		policy = watch.PolicyDrop
+	case newsv1.SlowConsumerPolicy_SLOW_CONSUMER_POLICY_DISCONNECT:
+		policy = watch.PolicyDisconnect
+	case newsv1.SlowConsumerPolicy_SLOW_CONSUMER_POLICY_UNSPECIFIED, newsv1.SlowConsumerPolicy_SLOW_CONSUMER_POLICY_BUFFER:
+	}
+
+	sub, err := s.watcher.Subscribe(watch.Filter{Tag: in.Tag, Author: in.Author}, in.SinceSeq, policy)
+	if err != nil {
+		return watchStatus(err)
+	}
+	defer sub.Close()
+
+	ctx := stream.Context()
+	privileged := s.privileged(ctx)
+	for {
+		event, err := sub.Next(ctx.Done())
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return status.FromContextError(ctxErr).Err() //nolint:wrapcheck // Status errors are returned as is.
+			}
+			return watchStatus(err)
+		}
+		if sendErr := stream.Send(toWatchResponse(event, privileged)); sendErr != nil {
+			return sendErr //nolint:wrapcheck // The stream errors are status errors.
+		}
+	}
+}
+
+// watchStatus maps the errors of the watch to gRPC status errors.
+func watchStatus(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, watch.ErrExpired):
+		code = codes.OutOfRange
+	case errors.Is(err, watch.ErrSlowConsumer):
+		code = codes.ResourceExhausted
+	case errors.Is(err, watch.ErrClosed):
+		code = codes.Unavailable
+	}
+	return status.Error(code, err.Error()) //nolint:wrapcheck // Status errors are returned as is.
+}
+
+func toWatchResponse(event watch.Event, privileged bool) *newsv1.WatchNewsResponse {
+	res := &newsv1.WatchNewsResponse{Seq: event.Seq}
+	switch event.Type {
+	case watch.EventCreated:
+		res.Type = newsv1.WatchNewsResponse_EVENT_TYPE_CREATED
+	case watch.EventUpdated:
+		res.Type = newsv1.WatchNewsResponse_EVENT_TYPE_UPDATED
+	case watch.EventDeleted:
+		res.Type = newsv1.WatchNewsResponse_EVENT_TYPE_DELETED
+	case watch.EventGap:
+		res.Type = newsv1.WatchNewsResponse_EVENT_TYPE_GAP
+		res.GapStartSeq = event.GapStart
+	}
+	if event.News != nil {
+		res.News = toVisibleNews(event.News, privileged)
+	}
+	return res
+}
@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"context"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/google/uuid"
+)
+
+// SubmitNews submits a draft for review.
+func (s *Server) SubmitNews(ctx context.Context, in *newsv1.TransitionNewsRequest) (*newsv1.News, error) {
+	return s.transition(ctx, in, memstore.TransitionSubmit)
+}
+
+// ApproveNews publishes a news in review.
+func (s *Server) ApproveNews(ctx context.Context, in *newsv1.TransitionNewsRequest) (*newsv1.News, error) {
+	return s.transition(ctx, in, memstore.TransitionApprove)
+}
+
+// RejectNews sends a news in review back to draft.
+func (s *Server) RejectNews(ctx context.Context, in *newsv1.TransitionNewsRequest) (*newsv1.News, error) {
+	return s.transition(ctx, in, memstore.TransitionReject)
+}
+
+// PublishNews publishes a draft without review, or an archived news again.
+func (s *Server) PublishNews(ctx context.Context, in *newsv1.TransitionNewsRequest) (*newsv1.News, error) {
+	return s.transition(ctx, in, memstore.TransitionPublish)
+}
+
+// ArchiveNews withdraws a published news from the readers.
+func (s *Server) ArchiveNews(ctx context.Context, in *newsv1.TransitionNewsRequest) (*newsv1.News, error) {
+	return s.transition(ctx, in, memstore.TransitionArchive)
+}
+
+func (s *Server) transition(
+	ctx context.Context, in *newsv1.TransitionNewsRequest, transition memstore.Transition,
+) (*newsv1.News, error) {
+	id, err := uuid.Parse(in.Id)
+	if err != nil {
+		return nil, invalidField("id", err)
+	}
+	movedNews, err := s.store.Transition(s.editing(ctx), id, transition, in.Version)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toNews(movedNews), nil
+}
+
+func toState(state memstore.State) newsv1.State {
+	switch state {
+	case memstore.StateDraft:
+		return newsv1.State_STATE_DRAFT
+	case memstore.StateInReview:
+		return newsv1.State_STATE_IN_REVIEW
+	case memstore.StatePublished:
+		return newsv1.State_STATE_PUBLISHED
+	case memstore.StateArchived:
+		return newsv1.State_STATE_ARCHIVED
+	case memstore.StateScheduled:
+		return newsv1.State_STATE_SCHEDULED
+	default:
+		return newsv1.State_STATE_UNSPECIFIED
+	}
+}
+
+// fromStates returns the states of the request, the published state when
+// there are none.
+func fromStates(states []newsv1.State) []memstore.State {
+	if len(states) == 0 {
+		return []memstore.State{memstore.StatePublished}
+	}
+	result := make([]memstore.State, 0, len(states))
+	for _, state := range states {
+		switch state {
+		case newsv1.State_STATE_DRAFT:
+			result = append(result, memstore.StateDraft)
+		case newsv1.State_STATE_IN_REVIEW:
+			result = append(result, memstore.StateInReview)
+		case newsv1.State_STATE_PUBLISHED:
+			result = append(result, memstore.StatePublished)
+		case newsv1.State_STATE_ARCHIVED:
+			result = append(result, memstore.StateArchived)
+		case newsv1.State_STATE_SCHEDULED:
+			result = append(result, memstore.StateScheduled)
+		case newsv1.State_STATE_UNSPECIFIED:
+		}
+	}
+	return result
+}
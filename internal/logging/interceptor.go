@@ -0,0 +1,205 @@
+// Package logging logs every call of the gRPC server as one structured
+// record, along with its payloads when enabled.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// RequestIDKey of the request ID in the metadata of the calls, set by the
+// client or generated by the server and sent back in the response headers.
+const RequestIDKey = "x-request-id"
+
+// maxRequestIDLen of the request IDs set by the clients, longer ones are
+// replaced.
+const maxRequestIDLen = 128
+
+// Option to configure the interceptors.
+type Option func(*logger)
+
+// WithPayloads logs the requests and responses of the unary calls, with the
+// fields of the names redacted at any depth.
+func WithPayloads(redacted ...string) Option {
+	return func(l *logger) {
+		l.payloads = true
+		for _, name := range redacted {
+			l.redacted[name] = true
+		}
+	}
+}
+
+// logger of the calls.
+type logger struct {
+	logger   *slog.Logger
+	payloads bool
+	redacted map[string]bool
+}
+
+func newLogger(l *slog.Logger, opts []Option) *logger {
+	lg := &logger{logger: l, redacted: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(lg)
+	}
+	return lg
+}
+
+// UnaryServerInterceptor logs the unary calls.
+func UnaryServerInterceptor(l *slog.Logger, opts ...Option) grpc.UnaryServerInterceptor {
+	lg := newLogger(l, opts)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx = withRequestID(ctx)
+		lg.sendRequestID(ctx, func(md metadata.MD) error { return grpc.SetHeader(ctx, md) })
+
+		res, err := handler(ctx, req)
+
+		attrs := lg.callAttrs(ctx, info.FullMethod, start, err)
+		if lg.payloads {
+			attrs = append(attrs, lg.payload("request", req))
+			if err == nil {
+				attrs = append(attrs, lg.payload("response", res))
+			}
+		}
+		lg.log(ctx, err, attrs)
+		return res, err
+	}
+}
+
+// StreamServerInterceptor logs the streaming calls along with the number of
+// messages received and sent.
+func StreamServerInterceptor(l *slog.Logger, opts ...Option) grpc.StreamServerInterceptor {
+	lg := newLogger(l, opts)
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		counted := &serverStream{ServerStream: stream, ctx: withRequestID(stream.Context())}
+		lg.sendRequestID(counted.ctx, stream.SetHeader)
+
+		err := handler(srv, counted)
+
+		attrs := append(lg.callAttrs(counted.ctx, info.FullMethod, start, err),
+			slog.Int("received", counted.received),
+			slog.Int("sent", counted.sent),
+		)
+		lg.log(counted.ctx, err, attrs)
+		return err
+	}
+}
+
+// sendRequestID sets the request ID of the call in the response headers. Not
+// every transport supports it, the ID is logged anyway.
+func (l *logger) sendRequestID(ctx context.Context, setHeader func(metadata.MD) error) {
+	if err := setHeader(metadata.Pairs(RequestIDKey, RequestID(ctx))); err != nil {
+		l.logger.DebugContext(ctx, "request id header", slog.String("error", err.Error()))
+	}
+}
+
+// callAttrs returns the attributes logged for every call.
+func (l *logger) callAttrs(ctx context.Context, method string, start time.Time, err error) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("request_id", RequestID(ctx)),
+		slog.Duration("duration", time.Since(start)),
+		slog.String("code", status.Code(err).String()),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		attrs = append(attrs, slog.String("peer", p.Addr.String()))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+	return attrs
+}
+
+func (l *logger) log(ctx context.Context, err error, attrs []slog.Attr) {
+	l.logger.LogAttrs(ctx, level(status.Code(err)), "rpc", attrs...)
+}
+
+// payload returns the attribute of the message, redacted.
+func (l *logger) payload(key string, m any) slog.Attr {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return slog.Any(key, m)
+	}
+	return slog.Any(key, payload{msg: redact(msg, l.redacted)})
+}
+
+// level of the calls ending with the code: the failures of the server are
+// errors, the ones of the clients are warnings.
+func level(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		return slog.LevelInfo
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return slog.LevelError
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied,
+		codes.ResourceExhausted, codes.FailedPrecondition, codes.Aborted, codes.OutOfRange, codes.Unauthenticated:
+	}
+	return slog.LevelWarn
+}
+
+type requestIDKey struct{}
+
+// RequestID returns the request ID of the call, empty outside of the
+// interceptors.
+func RequestID(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// withRequestID returns the context of the call with the request ID of its
+// metadata, or with a new one.
+//
+//nolint:ireturn // The context is derived.
+func withRequestID(ctx context.Context) context.Context {
+	values := metadata.ValueFromIncomingContext(ctx, RequestIDKey)
+	id := ""
+	if len(values) > 0 && len(values[0]) <= maxRequestIDLen {
+		id = values[0]
+	}
+	if id == "" {
+		id = uuid.NewString()
+	}
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// serverStream counts the messages of the stream.
+type serverStream struct {
+	grpc.ServerStream
+	//nolint:containedctx // The context of the stream is replaced.
+	ctx      context.Context
+	received int
+	sent     int
+}
+
+//nolint:ireturn // Required by grpc.ServerStream.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received++
+	}
+	return err //nolint:wrapcheck // io.EOF ends the stream and must be kept.
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent++
+	}
+	return err //nolint:wrapcheck // The status of the stream is kept.
+}
@@ -0,0 +1,267 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+
+	newsv1 "github.com/codeandlearn1991/news-grpc/api/news/v1"
+	"github.com/codeandlearn1991/news-grpc/internal/logging"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const method = "/news.v1.NewsService/Create"
+
+// transportStream records the headers set by the unary interceptor.
+type transportStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *transportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// records logged to the buffer by a JSON handler, one per line.
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	result := make([]map[string]any, 0, len(lines))
+	for _, line := range lines {
+		var record map[string]any
+		if err := json.Unmarshal(line, &record); err != nil {
+			t.Fatal(err)
+		}
+		result = append(result, record)
+	}
+	return result
+}
+
+// checkAttrs of the record.
+func checkAttrs(t *testing.T, record, want map[string]any) {
+	t.Helper()
+
+	for key, value := range want {
+		if record[key] != value {
+			t.Errorf("%s = %v, want %v", key, record[key], value)
+		}
+	}
+}
+
+// checkRequestID logged in the record, the wanted one or a generated one when
+// empty, which is also the one of the handler and the one of the header.
+func checkRequestID(t *testing.T, record map[string]any, want, handlerRequestID string, header metadata.MD) {
+	t.Helper()
+
+	requestID, ok := record["request_id"].(string)
+	if !ok || want != "" && requestID != want || want == "" && uuid.Validate(requestID) != nil {
+		t.Errorf("request_id = %q, want %q or a generated one", requestID, want)
+	}
+	if handlerRequestID != requestID {
+		t.Errorf("RequestID() of the handler = %q, want the logged %q", handlerRequestID, requestID)
+	}
+	if got := header.Get(logging.RequestIDKey); len(got) != 1 || got[0] != requestID {
+		t.Errorf("%s header = %q, want %q", logging.RequestIDKey, got, requestID)
+	}
+}
+
+// callContext returns the context of a call from the peer with the request ID
+// in its metadata, when not empty.
+func callContext(ctx context.Context, requestID string) context.Context {
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 1234}})
+	if requestID != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(logging.RequestIDKey, requestID))
+	}
+	return ctx
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		requestID string
+		err       error
+		wantLevel string
+		wantCode  string
+		// wantRequestID, generated when empty.
+		wantRequestID string
+	}{
+		{
+			name:          "ok",
+			requestID:     "request-1",
+			wantLevel:     "INFO",
+			wantCode:      "OK",
+			wantRequestID: "request-1",
+		},
+		{
+			name:      "client failure",
+			err:       status.Error(codes.NotFound, "news not found"),
+			wantLevel: "WARN",
+			wantCode:  "NotFound",
+		},
+		{
+			name:      "server failure",
+			requestID: strings.Repeat("a", 129),
+			err:       status.Error(codes.Internal, "store failure"),
+			wantLevel: "ERROR",
+			wantCode:  "Internal",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			interceptor := logging.UnaryServerInterceptor(slog.New(slog.NewJSONHandler(&buf, nil)))
+			stream := &transportStream{}
+			ctx := grpc.NewContextWithServerTransportStream(callContext(t.Context(), tc.requestID), stream)
+
+			var handlerRequestID string
+			_, err := interceptor(ctx, &newsv1.GetRequest{}, &grpc.UnaryServerInfo{FullMethod: method},
+				func(ctx context.Context, _ any) (any, error) {
+					handlerRequestID = logging.RequestID(ctx)
+					return &newsv1.GetResponse{}, tc.err
+				})
+			if err != tc.err { //nolint:errorlint // The error is returned as is.
+				t.Fatalf("interceptor error = %v, want %v", err, tc.err)
+			}
+
+			logged := records(t, &buf)
+			if len(logged) != 1 {
+				t.Fatalf("interceptor logged %d records, want 1", len(logged))
+			}
+			record := logged[0]
+			checkRequestID(t, record, tc.wantRequestID, handlerRequestID, stream.header)
+			checkAttrs(t, record, map[string]any{
+				"msg":    "rpc",
+				"level":  tc.wantLevel,
+				"method": method,
+				"code":   tc.wantCode,
+				"peer":   "192.0.2.1:1234",
+			})
+			if _, ok := record["duration"].(float64); !ok {
+				t.Errorf("duration = %v, want a number", record["duration"])
+			}
+			if got, want := record["error"], status.Convert(tc.err).Message(); tc.err != nil && got != want || tc.err == nil && got != nil {
+				t.Errorf("error = %v, want %q", got, want)
+			}
+			if _, ok := record["request"]; ok {
+				t.Errorf("request = %v, want no payload", record["request"])
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptorPayloads(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		err          error
+		wantResponse bool
+	}{
+		{name: "ok", wantResponse: true},
+		{name: "failure", err: status.Error(codes.InvalidArgument, "invalid")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			interceptor := logging.UnaryServerInterceptor(slog.New(slog.NewJSONHandler(&buf, nil)), logging.WithPayloads("content", "tags"))
+			req := &newsv1.CreateRequest{Title: "title", Content: "secret content", Tags: []string{"secret"}}
+			res := &newsv1.CreateResponse{Title: "title", Content: "secret content"}
+
+			_, err := interceptor(callContext(t.Context(), ""), req, &grpc.UnaryServerInfo{FullMethod: method},
+				func(context.Context, any) (any, error) { return res, tc.err })
+			if err != tc.err { //nolint:errorlint // The error is returned as is.
+				t.Fatalf("interceptor error = %v, want %v", err, tc.err)
+			}
+
+			record := records(t, &buf)[0]
+			wantRequest := map[string]any{"title": "title", "content": "[REDACTED]"}
+			if got, ok := record["request"].(map[string]any); !ok || len(got) != len(wantRequest) ||
+				got["title"] != wantRequest["title"] || got["content"] != wantRequest["content"] {
+				t.Errorf("request = %v, want %v", record["request"], wantRequest)
+			}
+			_, logged := record["response"]
+			if logged != tc.wantResponse {
+				t.Errorf("response logged = %t, want %t", logged, tc.wantResponse)
+			}
+			// The messages of the call are left as is.
+			if req.GetContent() != "secret content" || len(req.GetTags()) != 1 || res.GetContent() != "secret content" {
+				t.Errorf("interceptor redacted the messages of the call to %v and %v", req, res)
+			}
+		})
+	}
+}
+
+// serverStream receives the requests and records the headers.
+type serverStream struct {
+	grpc.ServerStream
+	//nolint:containedctx // The context of the stream.
+	ctx    context.Context
+	reqs   int
+	header metadata.MD
+}
+
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+func (s *serverStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *serverStream) RecvMsg(any) error {
+	if s.reqs == 0 {
+		return io.EOF
+	}
+	s.reqs--
+	return nil
+}
+
+func (s *serverStream) SendMsg(any) error { return nil }
+
+func TestStreamServerInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	interceptor := logging.StreamServerInterceptor(slog.New(slog.NewJSONHandler(&buf, nil)))
+	stream := &serverStream{ctx: callContext(t.Context(), "request-1"), reqs: 3}
+
+	err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: method}, func(_ any, ss grpc.ServerStream) error {
+		if got := logging.RequestID(ss.Context()); got != "request-1" {
+			t.Errorf("RequestID() of the handler = %q, want %q", got, "request-1")
+		}
+		for {
+			if err := ss.RecvMsg(nil); errors.Is(err, io.EOF) {
+				return nil
+			} else if err != nil {
+				return fmt.Errorf("receive: %w", err)
+			}
+			for range 2 {
+				if err := ss.SendMsg(nil); err != nil {
+					return fmt.Errorf("send: %w", err)
+				}
+			}
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkAttrs(t, records(t, &buf)[0], map[string]any{
+		"level":      "INFO",
+		"method":     method,
+		"code":       "OK",
+		"request_id": "request-1",
+		"received":   float64(3),
+		"sent":       float64(6),
+	})
+	if got := stream.header.Get(logging.RequestIDKey); len(got) != 1 || got[0] != "request-1" {
+		t.Errorf("%s header = %q, want %q", logging.RequestIDKey, got, "request-1")
+	}
+}
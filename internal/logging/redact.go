@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// redactedValue replaces the redacted string fields.
+const redactedValue = "[REDACTED]"
+
+// redact returns a copy of the message with the fields of the names
+// redacted, the message itself without any name.
+//
+//nolint:ireturn // The message is cloned.
+func redact(m proto.Message, names map[string]bool) proto.Message {
+	if len(names) == 0 {
+		return m
+	}
+	clone := proto.Clone(m)
+	redactMessage(clone.ProtoReflect(), names)
+	return clone
+}
+
+// redactMessage replaces the string fields of the names, clears the other
+// ones, and walks the nested messages.
+func redactMessage(m protoreflect.Message, names map[string]bool) {
+	// The fields are redacted once the message is walked, it must not change
+	// during the walk.
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case names[string(fd.Name())]:
+			fields = append(fields, fd)
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for i := range list.Len() {
+				redactMessage(list.Get(i).Message(), names)
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+				redactMessage(value.Message(), names)
+				return true
+			})
+		case fd.Message() != nil && !fd.IsMap():
+			redactMessage(v.Message(), names)
+		}
+		return true
+	})
+	for _, fd := range fields {
+		if fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() {
+			m.Set(fd, protoreflect.ValueOfString(redactedValue))
+		} else {
+			m.Clear(fd)
+		}
+	}
+}
+
+// payload logs a message as JSON, embedded as is by the JSON handlers.
+type payload struct {
+	msg proto.Message
+}
+
+func (p payload) MarshalJSON() ([]byte, error) {
+	return protojson.Marshal(p.msg) //nolint:wrapcheck // The handler reports it.
+}
+
+func (p payload) MarshalText() ([]byte, error) {
+	return p.MarshalJSON()
+}
@@ -0,0 +1,53 @@
+package memstore
+
+import (
+	"context"
+	"fmt"
+)
+
+// Change made by the last write of a news.
+type Change int
+
+// Changes of the news.
+const (
+	// ChangeCreated news was created.
+	ChangeCreated Change = iota + 1
+	// ChangeUpdated news was updated.
+	ChangeUpdated
+	// ChangeDeleted news was soft deleted.
+	ChangeDeleted
+)
+
+// ChangeOf returns the change made by the last write of the news.
+func ChangeOf(news *News) Change {
+	switch {
+	case !news.DeletedAt.IsZero():
+		return ChangeDeleted
+	case news.Version == 1:
+		return ChangeCreated
+	default:
+		return ChangeUpdated
+	}
+}
+
+// Changes returns up to limit news written after the sequence, in the order
+// of their last write and soft deleted news included. A news written several
+// times after the sequence is returned once, as of its last write. It fails
+// with ErrExpired when a news deleted after the sequence was purged since, as
+// the changes no longer tell about its deletion.
+func (s *Store) Changes(ctx context.Context, after int64, limit int) ([]*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("list changes: %w", err)
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if after > 0 && after < s.purged {
+		return nil, fmt.Errorf("%w: news deleted after %d were purged up to %d", ErrExpired, after, s.purged)
+	}
+	result := make([]*News, 0, min(limit, s.byChange.Len()))
+	s.byChange.AscendGreaterOrEqual(&News{Seq: after + 1}, func(news *News) bool {
+		result = append(result, news)
+		return len(result) < limit
+	})
+	return result, nil
+}
@@ -0,0 +1,22 @@
+package memstore
+
+import "context"
+
+type editorKey struct{}
+
+// WithEditor returns the context of the writes of the editor, who is recorded
+// as the last editor of the news they write.
+//
+//nolint:ireturn // The context is derived.
+func WithEditor(ctx context.Context, editor string) context.Context {
+	return context.WithValue(ctx, editorKey{}, editor)
+}
+
+// editorOf returns the editor of the writes of the context, or the previous
+// editor of the news when the context has none.
+func editorOf(ctx context.Context, previous string) string {
+	if editor, ok := ctx.Value(editorKey{}).(string); ok && editor != "" {
+		return editor
+	}
+	return previous
+}
@@ -0,0 +1,44 @@
+package memstore
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by the news stores, wrapped with details about the failure.
+var (
+	// ErrNotFound news does not exist or is deleted.
+	ErrNotFound = errors.New("news not found")
+	// ErrAlreadyDeleted news is deleted already, it is also an ErrNotFound.
+	ErrAlreadyDeleted = fmt.Errorf("%w: already deleted", ErrNotFound)
+	// ErrAlreadyExists news with the same id already exists.
+	ErrAlreadyExists = errors.New("news already exists")
+	// ErrConflict write conflicts with the current state of the news.
+	ErrConflict = errors.New("news conflict")
+	// ErrExpired changes since a sequence are no longer available.
+	ErrExpired = errors.New("news changes expired")
+	// ErrUnavailable store cannot serve the request at the moment.
+	ErrUnavailable = errors.New("news store unavailable")
+)
+
+// BatchError reports why a batch of writes was not applied. Its errors follow
+// the order of the batch, writes that would have succeeded have a nil error.
+type BatchError struct {
+	Errs []error
+}
+
+func (e *BatchError) Error() string {
+	failed := e.Unwrap()
+	return fmt.Sprintf("batch not applied, %d of %d writes failed: %v", len(failed), len(e.Errs), errors.Join(failed...))
+}
+
+// Unwrap returns the errors of the writes that failed.
+func (e *BatchError) Unwrap() []error {
+	failed := make([]error, 0, len(e.Errs))
+	for _, err := range e.Errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	return failed
+}
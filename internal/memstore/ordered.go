@@ -0,0 +1,58 @@
+package memstore
+
+import (
+	"time"
+
+	"github.com/google/btree"
+)
+
+// degree of the B-trees backing the ordered indexes.
+const degree = 32
+
+// entry of an ordered index.
+type entry struct {
+	cursor Cursor
+	news   *News
+}
+
+// orderedIndex keeps news sorted by creation time and then by id.
+type orderedIndex struct {
+	tree *btree.BTreeG[entry]
+}
+
+func newOrderedIndex() *orderedIndex {
+	return &orderedIndex{
+		tree: btree.NewG(degree, func(a, b entry) bool {
+			return compareCursor(a.cursor, b.cursor) < 0
+		}),
+	}
+}
+
+func (o *orderedIndex) len() int {
+	return o.tree.Len()
+}
+
+// insert the news, replacing the news at the same position if any.
+func (o *orderedIndex) insert(news *News) {
+	o.tree.ReplaceOrInsert(entry{cursor: cursorOf(news), news: news})
+}
+
+func (o *orderedIndex) remove(news *News) {
+	o.tree.Delete(entry{cursor: cursorOf(news)})
+}
+
+// ascend calls fn in order for the news positioned after the cursor, or
+// created at or after the timestamp, whichever comes last, until fn returns
+// false.
+func (o *orderedIndex) ascend(after *Cursor, createdAfter time.Time, fn func(news *News) bool) {
+	pivot := Cursor{CreatedAt: createdAfter}
+	if after != nil && compareCursor(*after, pivot) >= 0 {
+		pivot = *after
+	}
+	o.tree.AscendGreaterOrEqual(entry{cursor: pivot}, func(e entry) bool {
+		if after != nil && compareCursor(e.cursor, *after) == 0 {
+			return true
+		}
+		return fn(e.news)
+	})
+}
@@ -0,0 +1,81 @@
+package memstore
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cursor identifies a position in the listing order of the news.
+type Cursor struct {
+	// CreatedAt timestamp of the news at the position.
+	CreatedAt time.Time
+	// ID of the news at the position.
+	ID uuid.UUID
+}
+
+// Query to list news.
+type Query struct {
+	// Tag the news must be tagged with, matches all when empty.
+	Tag string
+	// Author of the news, matches all when empty.
+	Author string
+	// States the news must be in, matches all when empty.
+	States []State
+	// CreatedAfter matches news created at or after the timestamp when set.
+	CreatedAfter time.Time
+	// CreatedBefore matches news created before the timestamp when set.
+	CreatedBefore time.Time
+	// UpdatedAfter matches news updated at or after the timestamp when set.
+	UpdatedAfter time.Time
+	// UpdatedBefore matches news updated before the timestamp when set.
+	UpdatedBefore time.Time
+	// After only returns news positioned after the cursor when set.
+	After *Cursor
+	// Limit the number of news returned, zero means no limit.
+	Limit int
+}
+
+// Matches reports whether the news satisfies the filters of the query.
+func (q *Query) Matches(news *News) bool {
+	switch {
+	case q.Tag != "" && !slices.Contains(news.Tags, q.Tag):
+		return false
+	case q.Author != "" && news.Author != q.Author:
+		return false
+	case len(q.States) > 0 && !slices.Contains(q.States, news.State):
+		return false
+	case q.After != nil && compareCursor(cursorOf(news), *q.After) <= 0:
+		return false
+	}
+	return q.matchesTimes(news)
+}
+
+// matchesTimes reports whether the timestamps of the news are in the ranges
+// of the query.
+func (q *Query) matchesTimes(news *News) bool {
+	switch {
+	case !q.CreatedAfter.IsZero() && news.CreatedAt.Before(q.CreatedAfter):
+		return false
+	case !q.CreatedBefore.IsZero() && !news.CreatedAt.Before(q.CreatedBefore):
+		return false
+	case !q.UpdatedAfter.IsZero() && news.UpdatedAt.Before(q.UpdatedAfter):
+		return false
+	case !q.UpdatedBefore.IsZero() && !news.UpdatedAt.Before(q.UpdatedBefore):
+		return false
+	}
+	return true
+}
+
+func cursorOf(news *News) Cursor {
+	return Cursor{CreatedAt: news.CreatedAt, ID: news.ID}
+}
+
+// compareCursor orders cursors by creation time and then by id.
+func compareCursor(a, b Cursor) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return slices.Compare(a.ID[:], b.ID[:])
+}
@@ -0,0 +1,196 @@
+package memstore
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Revision is an immutable copy of a news taken on every create and update.
+type Revision struct {
+	// Number of the revision, the version of the news it captures.
+	Number int64
+	// News as of the revision.
+	News *News
+	// Author of the change.
+	Author string
+	// CreatedAt timestamp of the revision.
+	CreatedAt time.Time
+	// ChangedFields by the revision compared to the previous one.
+	ChangedFields []string
+}
+
+// FieldChange is the change of a news field between two revisions.
+type FieldChange struct {
+	// Field of the news.
+	Field string
+	// From value of the field.
+	From string
+	// To value of the field.
+	To string
+}
+
+// Diff returns the changes of the content and schedule fields from a news to
+// another.
+func Diff(from, to *News) []FieldChange {
+	changes := make([]FieldChange, 0)
+	for _, field := range []struct {
+		name     string
+		from, to string
+	}{
+		{"author", from.Author, to.Author},
+		{"title", from.Title, to.Title},
+		{"summary", from.Summary, to.Summary},
+		{"content", from.Content, to.Content},
+		{"source", sourceOf(from), sourceOf(to)},
+		{"tags", strings.Join(from.Tags, ", "), strings.Join(to.Tags, ", ")},
+		{"publish_at", timeOf(from.PublishAt), timeOf(to.PublishAt)},
+		{"embargo_until", timeOf(from.EmbargoUntil), timeOf(to.EmbargoUntil)},
+	} {
+		if field.from != field.to {
+			changes = append(changes, FieldChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+	return changes
+}
+
+func sourceOf(news *News) string {
+	if news.Source == nil {
+		return ""
+	}
+	return news.Source.String()
+}
+
+func timeOf(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// newRevision returns the revision of the news written after the previous
+// revision, which is nil for the first one. It is written by the editor of
+// the news, or by its author when the editor is unknown.
+func newRevision(news *News, previous *Revision) *Revision {
+	author := news.Editor
+	if author == "" {
+		author = news.Author
+	}
+	previousNews := &News{}
+	if previous != nil {
+		previousNews = previous.News
+	}
+
+	changedFields := make([]string, 0)
+	for _, change := range Diff(previousNews, news) {
+		changedFields = append(changedFields, change.Field)
+	}
+
+	return &Revision{
+		Number:        news.Version,
+		News:          news,
+		Author:        author,
+		CreatedAt:     news.UpdatedAt,
+		ChangedFields: changedFields,
+	}
+}
+
+// lastRevision of the news, nil when it has none. The caller must hold the
+// lock.
+func (s *Store) lastRevision(id uuid.UUID) *Revision {
+	revisions := s.revisions[id]
+	if len(revisions) == 0 {
+		return nil
+	}
+	return revisions[len(revisions)-1]
+}
+
+// Revisions of the news from the oldest to the latest, soft deleted news
+// included.
+func (s *Store) Revisions(ctx context.Context, id uuid.UUID) ([]*Revision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("list revisions: %w", err)
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if _, ok := s.news[id]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return slices.Clone(s.revisions[id]), nil
+}
+
+// History of the news, its revisions from the oldest to the latest. Unlike
+// Revisions, it does not fail for unknown news and returns no revisions.
+func (s *Store) History(id uuid.UUID) []*Revision {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return slices.Clone(s.revisions[id])
+}
+
+// Revision of the news by its number.
+func (s *Store) Revision(ctx context.Context, id uuid.UUID, number int64) (*Revision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("get revision: %w", err)
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.revision(id, number)
+}
+
+// revision of the news by its number, the caller must hold the lock.
+func (s *Store) revision(id uuid.UUID, number int64) (*Revision, error) {
+	revisions := s.revisions[id]
+	idx, found := slices.BinarySearchFunc(revisions, number, func(r *Revision, n int64) int {
+		return cmp.Compare(r.Number, n)
+	})
+	if !found {
+		return nil, fmt.Errorf("%w: revision %d of %s", ErrNotFound, number, id)
+	}
+	return revisions[idx], nil
+}
+
+// Rollback the content and the schedule of the news to the ones of a previous
+// revision, a published news being scheduled again until its release time.
+// The rollback is written as a new revision, and fails with ErrConflict when
+// the news is not at the expected version unless the version is zero.
+func (s *Store) Rollback(ctx context.Context, id uuid.UUID, number, version int64) (*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("rollback news: %w", err)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	news, ok := s.news[id]
+	if !ok || !news.DeletedAt.IsZero() {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	if err := checkVersion(news, version); err != nil {
+		return nil, err
+	}
+	revision, err := s.revision(id, number)
+	if err != nil {
+		return nil, err
+	}
+
+	rolledBack := *news
+	rolledBack.Author = revision.News.Author
+	rolledBack.Title = revision.News.Title
+	rolledBack.Summary = revision.News.Summary
+	rolledBack.Content = revision.News.Content
+	rolledBack.Source = revision.News.Source
+	rolledBack.Tags = revision.News.Tags
+	rolledBack.PublishAt = revision.News.PublishAt
+	rolledBack.EmbargoUntil = revision.News.EmbargoUntil
+	rolledBack.UpdatedAt = time.Now().UTC()
+	rolledBack.State = scheduleState(news.State, &rolledBack, rolledBack.UpdatedAt)
+	rolledBack.Version++
+	rolledBack.Editor = editorOf(ctx, news.Editor)
+	if err := s.commit(Write{News: &rolledBack, Revision: newRevision(&rolledBack, s.lastRevision(id))}); err != nil {
+		return nil, err
+	}
+	return &rolledBack, nil
+}
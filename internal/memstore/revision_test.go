@@ -0,0 +1,173 @@
+package memstore_test
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/google/uuid"
+)
+
+func TestDiff(t *testing.T) {
+	from := &memstore.News{
+		Author:  "author",
+		Title:   "title",
+		Source:  &url.URL{Scheme: "https", Host: "example.com"},
+		Tags:    []string{"first", "second"},
+		Content: "content",
+	}
+	to := *from
+	to.Title = "new title"
+	to.Source = nil
+	to.Tags = []string{"first"}
+	to.EmbargoUntil = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	want := []memstore.FieldChange{
+		{Field: "title", From: "title", To: "new title"},
+		{Field: "source", From: "https://example.com", To: ""},
+		{Field: "tags", From: "first, second", To: "first"},
+		{Field: "embargo_until", From: "", To: "2030-01-01T00:00:00Z"},
+	}
+	if got := memstore.Diff(from, &to); !slices.Equal(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+	if got := memstore.Diff(from, from); len(got) != 0 {
+		t.Errorf("Diff() of a news with itself = %+v, want none", got)
+	}
+}
+
+// revisedNews returns a store holding a news created with the title then
+// updated by the editor to each of the titles in turn.
+func revisedNews(t *testing.T, title string, titles ...string) (*memstore.Store, *memstore.News) {
+	t.Helper()
+
+	ctx := t.Context()
+	store := memstore.New()
+	news, err := store.Create(ctx, &memstore.News{Author: "author", Title: title, Content: "content"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, title := range titles {
+		updated := *news
+		updated.Title = title
+		if news, err = store.Update(memstore.WithEditor(ctx, "editor"), &updated); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return store, news
+}
+
+func TestStoreRevisions(t *testing.T) {
+	ctx := t.Context()
+	store, news := revisedNews(t, "first", "second")
+
+	revisions, err := store.Revisions(ctx, news.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		number        int64
+		title, author string
+		changedFields []string
+	}{
+		{1, "first", "author", []string{"author", "title", "content"}},
+		{2, "second", "editor", []string{"title"}},
+	}
+	if len(revisions) != len(want) {
+		t.Fatalf("Revisions() = %d revisions, want %d", len(revisions), len(want))
+	}
+	for i, revision := range revisions {
+		if revision.Number != want[i].number || revision.News.Title != want[i].title ||
+			revision.Author != want[i].author || !slices.Equal(revision.ChangedFields, want[i].changedFields) {
+			t.Errorf("revision %d = number %d of %q by %s changing %q, want number %d of %q by %s changing %q",
+				i, revision.Number, revision.News.Title, revision.Author, revision.ChangedFields,
+				want[i].number, want[i].title, want[i].author, want[i].changedFields)
+		}
+	}
+
+	if _, err = store.Revision(ctx, news.ID, 3); !errors.Is(err, memstore.ErrNotFound) {
+		t.Errorf("Revision() of a missing number error = %v, want %v", err, memstore.ErrNotFound)
+	}
+	if _, err = store.Revisions(ctx, uuid.New()); !errors.Is(err, memstore.ErrNotFound) {
+		t.Errorf("Revisions() of an unknown news error = %v, want %v", err, memstore.ErrNotFound)
+	}
+}
+
+func TestStoreRollback(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		number  int64
+		version func(news *memstore.News) int64
+		deleted bool
+		wantErr error
+	}{
+		{
+			name:    "any version",
+			number:  1,
+			version: func(*memstore.News) int64 { return 0 },
+		},
+		{
+			name:    "current version",
+			number:  1,
+			version: func(news *memstore.News) int64 { return news.Version },
+		},
+		{
+			name:    "stale version",
+			number:  1,
+			version: func(news *memstore.News) int64 { return news.Version - 1 },
+			wantErr: memstore.ErrConflict,
+		},
+		{
+			name:    "unknown revision",
+			number:  4,
+			version: func(*memstore.News) int64 { return 0 },
+			wantErr: memstore.ErrNotFound,
+		},
+		{
+			name:    "deleted news",
+			number:  1,
+			version: func(*memstore.News) int64 { return 0 },
+			deleted: true,
+			wantErr: memstore.ErrNotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			store, news := revisedNews(t, "first", "second", "third")
+			if tc.deleted {
+				if _, err := store.Delete(ctx, news.ID, 0); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			rolledBack, err := store.Rollback(memstore.WithEditor(ctx, "rollbacker"), news.ID, tc.number, tc.version(news))
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Rollback() error = %v, want %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if rolledBack.Title != "first" || rolledBack.Version != news.Version+1 {
+				t.Errorf("Rollback() = %q at version %d, want %q at version %d", rolledBack.Title, rolledBack.Version, "first", news.Version+1)
+			}
+
+			// The rollback is a new revision rather than a rewrite of the history.
+			revisions, err := store.Revisions(ctx, news.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			last := revisions[len(revisions)-1]
+			if len(revisions) != 4 || last.Number != rolledBack.Version || last.Author != "rollbacker" ||
+				!slices.Equal(last.ChangedFields, []string{"title"}) {
+				t.Errorf("Revisions() = %d, the last number %d by %s changing %q, want 4, the last number %d by rollbacker changing [\"title\"]",
+					len(revisions), last.Number, last.Author, last.ChangedFields, rolledBack.Version)
+			}
+			if revisions[2].News.Title != "third" {
+				t.Errorf("revision 3 title = %q after Rollback(), want %q", revisions[2].News.Title, "third")
+			}
+		})
+	}
+}
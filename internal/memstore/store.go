@@ -1,10 +1,15 @@
 package memstore
 
 import (
+	"cmp"
+	"context"
+	"fmt"
 	"net/url"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/google/btree"
 	"github.com/google/uuid"
 )
 
@@ -30,89 +35,551 @@ type News struct {
 	UpdatedAt time.Time
 	// DeletedAt timestamp of the news.
 	DeletedAt time.Time
+	// Version of the news, starting at 1 and incremented on every write.
+	Version int64
+	// State of the news in the editorial workflow.
+	State State
+	// PublishAt timestamp the news is published at once approved, right away
+	// when zero.
+	PublishAt time.Time
+	// EmbargoUntil timestamp the news is withheld until, even from being read
+	// by id.
+	EmbargoUntil time.Time
+	// Seq of the last write of the news, increasing with every write to the
+	// store.
+	Seq int64
+	// Editor of the last write of the news, empty when unknown.
+	Editor string
 }
 
-// Store in-memory implementation.
+// Indexer is notified of every news written to the store, soft deleted news
+// included, to keep secondary data such as search indexes in sync.
+type Indexer interface {
+	Index(news *News)
+}
+
+// Write of a news staged by the store, handed to the journal before it is
+// applied.
+type Write struct {
+	// News as written, or as it was when it is purged.
+	News *News
+	// Revision recorded by the write, nil when it records none.
+	Revision *Revision
+	// Purged news are removed for good along with their revisions.
+	Purged bool
+}
+
+// Journal makes the writes to the store durable. The writes of a call are
+// appended at once before they are applied, and none is applied when the
+// journal fails.
+type Journal interface {
+	Append(writes []Write) error
+}
+
+// Option to configure the store.
+type Option func(*Store)
+
+// WithIndexer registers an indexer notified on every write to the store.
+func WithIndexer(indexer Indexer) Option {
+	return func(s *Store) {
+		s.indexers = append(s.indexers, indexer)
+	}
+}
+
+// WithJournal registers the journal the writes are appended to before they
+// are applied.
+func WithJournal(journal Journal) Option {
+	return func(s *Store) {
+		s.journal = journal
+	}
+}
+
+// Store in-memory implementation. News are indexed by id, and live news are
+// additionally kept in ordered secondary indexes by creation time, tag and
+// author so that filtered listings only visit candidate news.
 type Store struct {
 	lock sync.RWMutex
-	news []*News
+	// news by id, soft deleted news included.
+	news       map[uuid.UUID]*News
+	byCreation *orderedIndex
+	byTag      map[string]*orderedIndex
+	byAuthor   map[string]*orderedIndex
+	// byChange holds the news by the sequence of their last write, soft
+	// deleted news included.
+	byChange *btree.BTreeG[*News]
+	// trash holds the soft deleted news by the sequence of their deletion.
+	trash *btree.BTreeG[*News]
+	// scheduled holds the scheduled news by their release time.
+	scheduled *btree.BTreeG[*News]
+	seq       int64
+	// purged is the sequence of the last write of the latest purged news.
+	purged    int64
+	revisions map[uuid.UUID][]*Revision
+	indexers  []Indexer
+	journal   Journal
 }
 
 // New constructor for the store.
-func New() *Store {
-	return &Store{
-		lock: sync.RWMutex{},
-		news: make([]*News, 0),
+func New(opts ...Option) *Store {
+	s := &Store{
+		lock:       sync.RWMutex{},
+		news:       make(map[uuid.UUID]*News),
+		byCreation: newOrderedIndex(),
+		byTag:      make(map[string]*orderedIndex),
+		byAuthor:   make(map[string]*orderedIndex),
+		byChange:   btree.NewG(degree, bySeq),
+		trash:      btree.NewG(degree, bySeq),
+		scheduled:  btree.NewG(degree, byRelease),
+		revisions:  make(map[uuid.UUID][]*Revision),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func bySeq(a, b *News) bool {
+	return a.Seq < b.Seq
+}
+
+// index notifies the indexers of the written news, the caller must hold the
+// write lock so that indexers observe writes in order.
+func (s *Store) index(news *News) {
+	for _, indexer := range s.indexers {
+		indexer.Index(news)
+	}
+}
+
+// put assigns the next sequence to the written news and stores it, the caller
+// must hold the write lock.
+func (s *Store) put(news *News) {
+	s.seq++
+	news.Seq = s.seq
+	s.replace(news)
+}
+
+// commit assigns the next sequences to the staged writes, appends them to the
+// journal and applies them once it succeeded. The caller must hold the write
+// lock.
+func (s *Store) commit(writes ...Write) error {
+	if len(writes) == 0 {
+		return nil
+	}
+	seq := s.seq
+	for _, w := range writes {
+		if !w.Purged {
+			seq++
+			w.News.Seq = seq
+		}
+	}
+	if s.journal != nil {
+		if err := s.journal.Append(writes); err != nil {
+			return fmt.Errorf("journal writes: %w", err)
+		}
+	}
+
+	for _, w := range writes {
+		if w.Purged {
+			s.purge(w.News.ID, w.News.Seq)
+			continue
+		}
+		s.replace(w.News)
+		if w.Revision != nil {
+			s.revisions[w.News.ID] = append(s.revisions[w.News.ID], w.Revision)
+		}
+	}
+	s.seq = max(s.seq, seq)
+	return nil
 }
 
-// Create news in the inmemory store.
-func (s *Store) Create(news *News) *News {
+// replace the news stored under its id and updates the secondary indexes,
+// the caller must hold the write lock.
+func (s *Store) replace(news *News) {
+	if previous, ok := s.news[news.ID]; ok {
+		s.unindex(previous)
+	}
+
+	s.news[news.ID] = news
+	s.byChange.ReplaceOrInsert(news)
+	if !news.DeletedAt.IsZero() {
+		s.trash.ReplaceOrInsert(news)
+	} else {
+		if news.State == StateScheduled {
+			s.scheduled.ReplaceOrInsert(news)
+		}
+		s.byCreation.insert(news)
+		for _, tag := range distinct(news.Tags) {
+			insertInto(s.byTag, tag, news)
+		}
+		insertInto(s.byAuthor, news.Author, news)
+	}
+
+	s.index(news)
+}
+
+// unindex removes the news from the secondary indexes, the caller must hold
+// the write lock.
+func (s *Store) unindex(news *News) {
+	s.byChange.Delete(news)
+	if !news.DeletedAt.IsZero() {
+		s.trash.Delete(news)
+		return
+	}
+	s.scheduled.Delete(news)
+	s.byCreation.remove(news)
+	for _, tag := range distinct(news.Tags) {
+		removeFrom(s.byTag, tag, news)
+	}
+	removeFrom(s.byAuthor, news.Author, news)
+}
+
+func insertInto(indexes map[string]*orderedIndex, key string, news *News) {
+	index, ok := indexes[key]
+	if !ok {
+		index = newOrderedIndex()
+		indexes[key] = index
+	}
+	index.insert(news)
+}
+
+func removeFrom(indexes map[string]*orderedIndex, key string, news *News) {
+	index, ok := indexes[key]
+	if !ok {
+		return
+	}
+	index.remove(news)
+	if index.len() == 0 {
+		delete(indexes, key)
+	}
+}
+
+func distinct(values []string) []string {
+	result := slices.Clone(values)
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// Create news in the inmemory store under the id of the news, a new id is
+// generated when it is not set. Creating again a news identical to a live one
+// returns the stored news so that creates can be retried safely, while any
+// other news already stored under the id fails with ErrAlreadyExists.
+func (s *Store) Create(ctx context.Context, news *News) (*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("create news: %w", err)
+	}
+	id := news.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
 	createdNews := &News{
-		ID:        uuid.New(),
-		Author:    news.Author,
-		Title:     news.Title,
-		Summary:   news.Summary,
-		Content:   news.Content,
-		Source:    news.Source,
-		Tags:      news.Tags,
-		CreatedAt: time.Now().UTC(),
-		UpdatedAt: time.Now().UTC(),
+		ID:           id,
+		Author:       news.Author,
+		Title:        news.Title,
+		Summary:      news.Summary,
+		Content:      news.Content,
+		Source:       news.Source,
+		Tags:         news.Tags,
+		CreatedAt:    time.Now().UTC(),
+		UpdatedAt:    time.Now().UTC(),
+		Version:      1,
+		State:        StateDraft,
+		PublishAt:    news.PublishAt,
+		EmbargoUntil: news.EmbargoUntil,
+		Editor:       editorOf(ctx, ""),
 	}
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	s.news = append(s.news, createdNews)
-	return createdNews
+	if existing, ok := s.news[id]; ok {
+		if existing.DeletedAt.IsZero() && sameContent(existing, createdNews) {
+			return existing, nil
+		}
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyExists, id)
+	}
+	if err := s.commit(Write{News: createdNews, Revision: newRevision(createdNews, nil)}); err != nil {
+		return nil, err
+	}
+	return createdNews, nil
+}
+
+// sameContent reports whether both news carry the same content, regardless
+// of their timestamps.
+func sameContent(a, b *News) bool {
+	return a.Author == b.Author &&
+		a.Title == b.Title &&
+		a.Summary == b.Summary &&
+		a.Content == b.Content &&
+		a.Source.String() == b.Source.String() &&
+		slices.Equal(a.Tags, b.Tags) &&
+		a.PublishAt.Equal(b.PublishAt) &&
+		a.EmbargoUntil.Equal(b.EmbargoUntil)
 }
 
 // Get news by it's id.
-func (s *Store) Get(id uuid.UUID) *News {
+func (s *Store) Get(ctx context.Context, id uuid.UUID) (*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("get news: %w", err)
+	}
 	s.lock.RLock()
 	defer s.lock.RUnlock()
-	for _, news := range s.news {
-		if news.ID == id && news.DeletedAt.IsZero() {
-			return news
-		}
+	if news, ok := s.news[id]; ok && news.DeletedAt.IsZero() {
+		return news, nil
 	}
-	return nil
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// GetMany news by their ids in the order of the ids, from a single consistent
+// view of the store. Unknown ids are left nil and soft deleted news are
+// returned as is, so that callers can tell them apart.
+func (s *Store) GetMany(ctx context.Context, ids []uuid.UUID) ([]*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("get news: %w", err)
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	result := make([]*News, len(ids))
+	for i, id := range ids {
+		result[i] = s.news[id]
+	}
+	return result, nil
 }
 
-// GetAll news.
-func (s *Store) GetAll() []*News {
+// GetAll news ordered by creation time and then by id.
+func (s *Store) GetAll(ctx context.Context) ([]*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("get all news: %w", err)
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	result := make([]*News, 0, s.byCreation.len())
+
+	s.byCreation.ascend(nil, time.Time{}, func(news *News) bool {
+		result = append(result, news)
+		return true
+	})
+
+	return result, nil
+}
+
+// List news matching the query ordered by creation time and then by id.
+func (s *Store) List(ctx context.Context, q *Query) ([]*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("list news: %w", err)
+	}
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 
 	result := make([]*News, 0)
 
-	for _, news := range s.news {
-		if news.DeletedAt.IsZero() {
+	index, ok := s.indexFor(q)
+	if !ok {
+		return result, nil
+	}
+
+	index.ascend(q.After, q.CreatedAfter, func(news *News) bool {
+		if !q.CreatedBefore.IsZero() && !news.CreatedAt.Before(q.CreatedBefore) {
+			return false
+		}
+		if q.Matches(news) {
 			result = append(result, news)
 		}
+		return q.Limit <= 0 || len(result) < q.Limit
+	})
+
+	return result, nil
+}
+
+// indexFor returns the smallest index covering the query, the remaining
+// filters being checked on each news of the index. It returns false when no
+// news can match the query.
+func (s *Store) indexFor(q *Query) (*orderedIndex, bool) {
+	index := s.byCreation
+	if q.Tag != "" {
+		byTag, ok := s.byTag[q.Tag]
+		if !ok {
+			return nil, false
+		}
+		index = byTag
 	}
+	if q.Author != "" {
+		byAuthor, ok := s.byAuthor[q.Author]
+		if !ok {
+			return nil, false
+		}
+		if byAuthor.len() < index.len() {
+			index = byAuthor
+		}
+	}
+	return index, true
+}
 
-	return result
+// Update news and record it as a new revision. The version of the updated
+// news is the version it is expected to replace, the update fails with
+// ErrConflict when the stored news is at another version. A zero version
+// skips the check.
+func (s *Store) Update(ctx context.Context, updatedNews *News) (*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("update news: %w", err)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	news, ok := s.news[updatedNews.ID]
+	if !ok || !news.DeletedAt.IsZero() {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, updatedNews.ID)
+	}
+	if err := checkVersion(news, updatedNews.Version); err != nil {
+		return nil, err
+	}
+	storedNews := updated(ctx, news, updatedNews)
+	if err := s.commit(Write{News: storedNews, Revision: newRevision(storedNews, s.lastRevision(news.ID))}); err != nil {
+		return nil, err
+	}
+	return storedNews, nil
 }
 
-// Update news.
-func (s *Store) Update(updatedNews *News) {
+// UpdateAll updates the news at once: either every update succeeds, or none
+// is applied and the failure of each update is reported by a *BatchError.
+// Updates of the same news apply in order, each one expecting the version
+// left by the previous one.
+func (s *Store) UpdateAll(ctx context.Context, updates []*News) ([]*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("update news: %w", err)
+	}
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	for idx, news := range s.news {
-		if updatedNews.ID == news.ID && news.DeletedAt.IsZero() {
-			s.news[idx] = updatedNews
-			return
+
+	pending := make(map[uuid.UUID]Write, len(updates))
+	writes := make([]Write, len(updates))
+	errs := make([]error, len(updates))
+	failed := false
+	for i, updatedNews := range updates {
+		previous, ok := pending[updatedNews.ID]
+		if !ok {
+			previous = Write{News: s.news[updatedNews.ID], Revision: s.lastRevision(updatedNews.ID)}
+		}
+		news := previous.News
+		if news == nil || !news.DeletedAt.IsZero() {
+			errs[i] = fmt.Errorf("%w: %s", ErrNotFound, updatedNews.ID)
+			failed = true
+			continue
 		}
+		if err := checkVersion(news, updatedNews.Version); err != nil {
+			errs[i] = err
+			failed = true
+			continue
+		}
+		storedNews := updated(ctx, news, updatedNews)
+		writes[i] = Write{News: storedNews, Revision: newRevision(storedNews, previous.Revision)}
+		pending[updatedNews.ID] = writes[i]
+	}
+	if failed {
+		return nil, &BatchError{Errs: errs}
+	}
+
+	if err := s.commit(writes...); err != nil {
+		return nil, err
+	}
+	storedNews := make([]*News, 0, len(writes))
+	for _, w := range writes {
+		storedNews = append(storedNews, w.News)
+	}
+	return storedNews, nil
+}
+
+// updated returns the news to store in place of the news for the update.
+// The state only changes through transitions, or when the update moves the
+// release time of a published or scheduled news.
+func updated(ctx context.Context, news, updatedNews *News) *News {
+	storedNews := *updatedNews
+	storedNews.Editor = editorOf(ctx, news.Editor)
+	storedNews.CreatedAt = news.CreatedAt
+	storedNews.UpdatedAt = time.Now().UTC()
+	storedNews.State = scheduleState(news.State, &storedNews, storedNews.UpdatedAt)
+	storedNews.Version = news.Version + 1
+	return &storedNews
+}
+
+// Delete news from store and return it as deleted. It fails with ErrConflict
+// when the stored news is not at the expected version, a zero version skips
+// the check. Deleting a deleted news fails with ErrAlreadyDeleted and returns
+// the news as it was deleted, the deletion is not stamped again.
+func (s *Store) Delete(ctx context.Context, id uuid.UUID, version int64) (*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("delete news: %w", err)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	news, ok := s.news[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	if !news.DeletedAt.IsZero() {
+		return news, fmt.Errorf("%w: %s", ErrAlreadyDeleted, id)
 	}
+	if err := checkVersion(news, version); err != nil {
+		return nil, err
+	}
+	// Stored news are never mutated in place as readers may hold them.
+	deletedNews := *news
+	deletedNews.DeletedAt = time.Now().UTC()
+	deletedNews.Version++
+	deletedNews.Editor = editorOf(ctx, news.Editor)
+	if err := s.commit(Write{News: &deletedNews}); err != nil {
+		return nil, err
+	}
+	return &deletedNews, nil
+}
+
+// checkVersion fails with ErrConflict when the news is not at the expected
+// version, unless the expected version is zero.
+func checkVersion(news *News, expected int64) error {
+	if expected != 0 && news.Version != expected {
+		return fmt.Errorf("%w: news %s is at version %d, not %d", ErrConflict, news.ID, news.Version, expected)
+	}
+	return nil
 }
 
-// Delete news from store.
-func (s *Store) Delete(id uuid.UUID) {
+// Load the news and its revisions into the store as is, replacing any news
+// or revisions with the same id and number. It is meant to restore the store
+// from a durable copy, in the order of the writes. News loaded without a
+// sequence are assigned the next one.
+func (s *Store) Load(loaded *News, revisions ...*Revision) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	for idx, news := range s.news {
-		if id == news.ID {
-			s.news[idx].DeletedAt = time.Now().UTC()
-			return
+	if loaded.Seq == 0 {
+		s.put(loaded)
+	} else {
+		s.seq = max(s.seq, loaded.Seq)
+		s.replace(loaded)
+	}
+	for _, revision := range revisions {
+		stored := s.revisions[loaded.ID]
+		idx, found := slices.BinarySearchFunc(stored, revision.Number, func(r *Revision, n int64) int {
+			return cmp.Compare(r.Number, n)
+		})
+		if found {
+			stored[idx] = revision
+			continue
 		}
+		s.revisions[loaded.ID] = slices.Insert(stored, idx, revision)
 	}
 }
+
+// Lookup news by it's id, soft deleted news included.
+func (s *Store) Lookup(id uuid.UUID) *News {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.news[id]
+}
+
+// All news in the order of their last write, soft deleted news included.
+func (s *Store) All() []*News {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	result := make([]*News, 0, len(s.news))
+	s.byChange.Ascend(func(news *News) bool {
+		result = append(result, news)
+		return true
+	})
+	return result
+}
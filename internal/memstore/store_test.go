@@ -0,0 +1,138 @@
+package memstore_test
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/google/uuid"
+)
+
+const (
+	benchmarkSize    = 100_000
+	benchmarkTags    = 100
+	benchmarkAuthors = 1_000
+)
+
+// newBenchmarkStore returns a store filled with benchmarkSize news along with
+// their ids.
+func newBenchmarkStore(b *testing.B) (*memstore.Store, []uuid.UUID) {
+	b.Helper()
+
+	ctx := b.Context()
+	source, err := url.Parse("https://example.com")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	store := memstore.New()
+	ids := make([]uuid.UUID, 0, benchmarkSize)
+	for i := range benchmarkSize {
+		created, err := store.Create(ctx, &memstore.News{
+			Author:  fmt.Sprintf("author %d", i%benchmarkAuthors),
+			Title:   fmt.Sprintf("title %d", i),
+			Summary: fmt.Sprintf("summary %d", i),
+			Content: fmt.Sprintf("content %d", i),
+			Source:  source,
+			Tags:    []string{fmt.Sprintf("tag %d", i%benchmarkTags), "all"},
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		ids = append(ids, created.ID)
+	}
+
+	return store, ids
+}
+
+func BenchmarkStoreGet(b *testing.B) {
+	store, ids := newBenchmarkStore(b)
+	ctx := b.Context()
+
+	b.ResetTimer()
+	for i := range b.N {
+		if _, err := store.Get(ctx, ids[i%len(ids)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStoreUpdate(b *testing.B) {
+	store, ids := newBenchmarkStore(b)
+	ctx := b.Context()
+
+	b.ResetTimer()
+	for i := range b.N {
+		news, err := store.Get(ctx, ids[i%len(ids)])
+		if err != nil {
+			b.Fatal(err)
+		}
+		updated := *news
+		updated.Title = fmt.Sprintf("updated title %d", i)
+		if _, err = store.Update(ctx, &updated); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStoreDelete(b *testing.B) {
+	store, ids := newBenchmarkStore(b)
+	ctx := b.Context()
+
+	b.ResetTimer()
+	for i := range b.N {
+		if i > 0 && i%len(ids) == 0 {
+			b.StopTimer()
+			store, ids = newBenchmarkStore(b)
+			b.StartTimer()
+		}
+		if _, err := store.Delete(ctx, ids[i%len(ids)], 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStoreList(b *testing.B) {
+	store, _ := newBenchmarkStore(b)
+	ctx := b.Context()
+
+	for _, bc := range []struct {
+		name  string
+		query *memstore.Query
+	}{
+		{"all", &memstore.Query{Limit: 50}},
+		{"tag", &memstore.Query{Tag: "tag 42", Limit: 50}},
+		{"author", &memstore.Query{Author: "author 42", Limit: 50}},
+		{"tag and author", &memstore.Query{Tag: "tag 42", Author: "author 42", Limit: 50}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			for range b.N {
+				if _, err := store.List(ctx, bc.query); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkStoreListPages(b *testing.B) {
+	store, _ := newBenchmarkStore(b)
+	ctx := b.Context()
+
+	b.ResetTimer()
+	for range b.N {
+		query := memstore.Query{Tag: "all", Limit: 1_000}
+		for {
+			page, err := store.List(ctx, &query)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(page) < query.Limit {
+				break
+			}
+			last := page[len(page)-1]
+			query.After = &memstore.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package memstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Trash returns up to limit soft deleted news deleted after the sequence, in
+// the order of their deletion.
+func (s *Store) Trash(ctx context.Context, after int64, limit int) ([]*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("list trash: %w", err)
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	result := make([]*News, 0, min(limit, s.trash.Len()))
+	s.trash.AscendGreaterOrEqual(&News{Seq: after + 1}, func(news *News) bool {
+		result = append(result, news)
+		return len(result) < limit
+	})
+	return result, nil
+}
+
+// Restore a soft deleted news. It fails with ErrConflict when the news is not
+// deleted or not at the expected version, a zero version skips the check.
+func (s *Store) Restore(ctx context.Context, id uuid.UUID, version int64) (*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("restore news: %w", err)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	news, ok := s.news[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	if news.DeletedAt.IsZero() {
+		return nil, fmt.Errorf("%w: news %s is not deleted", ErrConflict, id)
+	}
+	if err := checkVersion(news, version); err != nil {
+		return nil, err
+	}
+	restoredNews := *news
+	restoredNews.DeletedAt = time.Time{}
+	restoredNews.UpdatedAt = time.Now().UTC()
+	restoredNews.Version++
+	restoredNews.Editor = editorOf(ctx, news.Editor)
+	if err := s.commit(Write{News: &restoredNews}); err != nil {
+		return nil, err
+	}
+	return &restoredNews, nil
+}
+
+// Purge soft deleted news permanently, along with their revisions. Either all
+// of them are purged, or none when one of them is not in the trash, which
+// fails with ErrNotFound or ErrConflict when it is not deleted. An id given
+// more than once is purged once.
+func (s *Store) Purge(ctx context.Context, ids ...uuid.UUID) ([]*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("purge news: %w", err)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	purged := make([]*News, 0, len(ids))
+	writes := make([]Write, 0, len(ids))
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		news, ok := s.news[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		if news.DeletedAt.IsZero() {
+			return nil, fmt.Errorf("%w: news %s is not deleted", ErrConflict, id)
+		}
+		purged = append(purged, news)
+		writes = append(writes, Write{News: news, Purged: true})
+	}
+	if err := s.commit(writes...); err != nil {
+		return nil, err
+	}
+	return purged, nil
+}
+
+// PurgeDeleted permanently purges the news soft deleted before the time,
+// along with their revisions.
+func (s *Store) PurgeDeleted(ctx context.Context, before time.Time) ([]*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("purge news: %w", err)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var (
+		purged []*News
+		writes []Write
+	)
+	s.trash.Ascend(func(news *News) bool {
+		if news.DeletedAt.Before(before) {
+			purged = append(purged, news)
+			writes = append(writes, Write{News: news, Purged: true})
+		}
+		return true
+	})
+	if err := s.commit(writes...); err != nil {
+		return nil, err
+	}
+	return purged, nil
+}
+
+// Unload a purged news from the store. It is meant to restore the store from
+// a durable copy, seq being the sequence of the last write of the news.
+func (s *Store) Unload(id uuid.UUID, seq int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.purge(id, seq)
+}
+
+// Purged returns the sequence of the last write of the latest purged news.
+func (s *Store) Purged() int64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.purged
+}
+
+// Stats of the stored news.
+type Stats struct {
+	// Live news, whatever their state.
+	Live int
+	// Deleted news, in the trash.
+	Deleted int
+}
+
+// Stats returns the number of live and soft deleted news.
+func (s *Store) Stats() Stats {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	deleted := s.trash.Len()
+	return Stats{Live: len(s.news) - deleted, Deleted: deleted}
+}
+
+// purge removes the news and its revisions, the caller must hold the write
+// lock.
+func (s *Store) purge(id uuid.UUID, seq int64) {
+	if news, ok := s.news[id]; ok {
+		s.unindex(news)
+		delete(s.news, id)
+		delete(s.revisions, id)
+	}
+	s.purged = max(s.purged, seq)
+	s.seq = max(s.seq, seq)
+}
@@ -0,0 +1,271 @@
+package memstore_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/google/uuid"
+)
+
+// journal records the writes appended to it.
+type journal struct {
+	appends [][]memstore.Write
+}
+
+func (j *journal) Append(writes []memstore.Write) error {
+	j.appends = append(j.appends, writes)
+	return nil
+}
+
+// trashedStore returns a store holding the news of the titles, the deleted
+// ones deleted in their order, along with the news by title.
+func trashedStore(
+	t *testing.T, opts []memstore.Option, live []string, deleted ...string,
+) (store *memstore.Store, byTitle map[string]*memstore.News) {
+	t.Helper()
+
+	ctx := t.Context()
+	store = memstore.New(opts...)
+	byTitle = make(map[string]*memstore.News)
+	for _, title := range append(slices.Clone(live), deleted...) {
+		news, err := store.Create(ctx, &memstore.News{Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+		byTitle[title] = news
+	}
+	for _, title := range deleted {
+		news, err := store.Delete(ctx, byTitle[title].ID, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		byTitle[title] = news
+	}
+	return store, byTitle
+}
+
+func titles(news []*memstore.News) []string {
+	result := make([]string, 0, len(news))
+	for _, n := range news {
+		result = append(result, n.Title)
+	}
+	return result
+}
+
+func TestStoreTrash(t *testing.T) {
+	store, byTitle := trashedStore(t, nil, []string{"live"}, "first", "second", "third")
+
+	for _, tc := range []struct {
+		name  string
+		after int64
+		limit int
+		want  []string
+	}{
+		{"all", 0, 10, []string{"first", "second", "third"}},
+		{"first page", 0, 2, []string{"first", "second"}},
+		{"next page", byTitle["second"].Seq, 2, []string{"third"}},
+		{"past the last", byTitle["third"].Seq, 2, []string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			trash, err := store.Trash(t.Context(), tc.after, tc.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := titles(trash); !slices.Equal(got, tc.want) {
+				t.Errorf("Trash() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStoreRestore(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		title   string
+		version func(news *memstore.News) int64
+		wantErr error
+	}{
+		{
+			name:    "deleted",
+			title:   "deleted",
+			version: func(*memstore.News) int64 { return 0 },
+		},
+		{
+			name:    "expected version",
+			title:   "deleted",
+			version: func(news *memstore.News) int64 { return news.Version },
+		},
+		{
+			name:    "stale version",
+			title:   "deleted",
+			version: func(news *memstore.News) int64 { return news.Version - 1 },
+			wantErr: memstore.ErrConflict,
+		},
+		{
+			name:    "live",
+			title:   "live",
+			version: func(*memstore.News) int64 { return 0 },
+			wantErr: memstore.ErrConflict,
+		},
+		{
+			name:    "unknown",
+			version: func(*memstore.News) int64 { return 0 },
+			wantErr: memstore.ErrNotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			store, byTitle := trashedStore(t, nil, []string{"live"}, "deleted")
+			id := uuid.New()
+			news := &memstore.News{ID: id, Version: 1}
+			if known, ok := byTitle[tc.title]; ok {
+				id, news = known.ID, known
+			}
+
+			restored, err := store.Restore(ctx, id, tc.version(news))
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Restore() error = %v, want %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !restored.DeletedAt.IsZero() || restored.Version != news.Version+1 {
+				t.Errorf("Restore() deleted at %v, version %d, want live at version %d", restored.DeletedAt, restored.Version, news.Version+1)
+			}
+			if _, err = store.Get(ctx, id); err != nil {
+				t.Errorf("Get() error = %v after Restore()", err)
+			}
+			trash, err := store.Trash(ctx, 0, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(trash) != 0 {
+				t.Errorf("Trash() = %q after Restore(), want none", titles(trash))
+			}
+		})
+	}
+}
+
+func TestStorePurge(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		titles []string
+		// unknown appends an unknown id to the purged ones.
+		unknown     bool
+		wantPurged  []string
+		wantErr     error
+		wantRemains []string
+	}{
+		{
+			name:        "one",
+			titles:      []string{"first"},
+			wantPurged:  []string{"first"},
+			wantRemains: []string{"second"},
+		},
+		{
+			name:       "many",
+			titles:     []string{"second", "first"},
+			wantPurged: []string{"second", "first"},
+		},
+		{
+			name:        "same twice",
+			titles:      []string{"first", "first"},
+			wantPurged:  []string{"first"},
+			wantRemains: []string{"second"},
+		},
+		{
+			name:        "live",
+			titles:      []string{"first", "live"},
+			wantErr:     memstore.ErrConflict,
+			wantRemains: []string{"first", "second"},
+		},
+		{
+			name:        "unknown",
+			titles:      []string{"first"},
+			unknown:     true,
+			wantErr:     memstore.ErrNotFound,
+			wantRemains: []string{"first", "second"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			journal := &journal{}
+			store, byTitle := trashedStore(t, []memstore.Option{memstore.WithJournal(journal)}, []string{"live"}, "first", "second")
+			ids := make([]uuid.UUID, 0, len(tc.titles)+1)
+			for _, title := range tc.titles {
+				ids = append(ids, byTitle[title].ID)
+			}
+			if tc.unknown {
+				ids = append(ids, uuid.New())
+			}
+			appends := len(journal.appends)
+
+			purged, err := store.Purge(ctx, ids...)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Purge() error = %v, want %v", err, tc.wantErr)
+			}
+			if got := titles(purged); err == nil && !slices.Equal(got, tc.wantPurged) {
+				t.Errorf("Purge() = %q, want %q", got, tc.wantPurged)
+			}
+
+			// The purge is a single write of every purged news, none on failure.
+			var written []string
+			for _, writes := range journal.appends[appends:] {
+				for _, write := range writes {
+					if !write.Purged {
+						t.Errorf("Purge() wrote %q without purging it", write.News.Title)
+					}
+					written = append(written, write.News.Title)
+				}
+			}
+			if wantAppends := min(len(tc.wantPurged), 1); len(journal.appends)-appends != wantAppends {
+				t.Errorf("Purge() appended %d times, want %d", len(journal.appends)-appends, wantAppends)
+			}
+			if !slices.Equal(written, tc.wantPurged) {
+				t.Errorf("Purge() wrote %q, want %q", written, tc.wantPurged)
+			}
+
+			trash, err := store.Trash(ctx, 0, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := titles(trash); !slices.Equal(got, tc.wantRemains) {
+				t.Errorf("Trash() = %q after Purge(), want %q", got, tc.wantRemains)
+			}
+		})
+	}
+}
+
+func TestStorePurgeDeleted(t *testing.T) {
+	ctx := t.Context()
+	store, _ := trashedStore(t, nil, []string{"live"}, "first")
+	before := time.Now()
+	time.Sleep(time.Millisecond)
+	if _, err := store.Delete(ctx, mustCreate(t, store, "second").ID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	purged, err := store.PurgeDeleted(ctx, before)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := titles(purged), []string{"first"}; !slices.Equal(got, want) {
+		t.Errorf("PurgeDeleted() = %q, want %q", got, want)
+	}
+	if got, want := store.Stats(), (memstore.Stats{Live: 1, Deleted: 1}); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func mustCreate(t *testing.T, store *memstore.Store, title string) *memstore.News {
+	t.Helper()
+
+	news, err := store.Create(t.Context(), &memstore.News{Title: title})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return news
+}
@@ -0,0 +1,210 @@
+package memstore
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// State of the news in the editorial workflow.
+type State int
+
+// States of the news.
+const (
+	// StateDraft news is being written, news are created as drafts.
+	StateDraft State = iota + 1
+	// StateInReview news was submitted and waits for a review.
+	StateInReview
+	// StatePublished news is visible to the readers.
+	StatePublished
+	// StateArchived news was withdrawn from the readers.
+	StateArchived
+	// StateScheduled news was published ahead of its release time, it is
+	// published once the time is reached.
+	StateScheduled
+)
+
+func (s State) String() string {
+	switch s {
+	case StateDraft:
+		return "draft"
+	case StateInReview:
+		return "in review"
+	case StatePublished:
+		return "published"
+	case StateArchived:
+		return "archived"
+	case StateScheduled:
+		return "scheduled"
+	default:
+		return fmt.Sprintf("state %d", int(s))
+	}
+}
+
+// Transition of the news from a state to another.
+type Transition int
+
+// Transitions of the workflow.
+const (
+	// TransitionSubmit submits a draft for review.
+	TransitionSubmit Transition = iota + 1
+	// TransitionApprove publishes a news in review, or schedules it until its
+	// release time.
+	TransitionApprove
+	// TransitionReject sends a news in review back to draft.
+	TransitionReject
+	// TransitionPublish publishes a draft without review, or an archived news
+	// again. The news is scheduled until its release time.
+	TransitionPublish
+	// TransitionArchive withdraws a published or scheduled news.
+	TransitionArchive
+)
+
+func (t Transition) String() string {
+	switch t {
+	case TransitionSubmit:
+		return "submit"
+	case TransitionApprove:
+		return "approve"
+	case TransitionReject:
+		return "reject"
+	case TransitionPublish:
+		return "publish"
+	case TransitionArchive:
+		return "archive"
+	default:
+		return fmt.Sprintf("transition %d", int(t))
+	}
+}
+
+// workflow holds the states each transition moves the news from, and the
+// state it moves them to.
+var workflow = map[Transition]struct {
+	from []State
+	to   State
+}{
+	TransitionSubmit:  {from: []State{StateDraft}, to: StateInReview},
+	TransitionApprove: {from: []State{StateInReview}, to: StatePublished},
+	TransitionReject:  {from: []State{StateInReview}, to: StateDraft},
+	TransitionPublish: {from: []State{StateDraft, StateArchived}, to: StatePublished},
+	TransitionArchive: {from: []State{StatePublished, StateScheduled}, to: StateArchived},
+}
+
+// ReleaseAt returns the time the news can be published at, the latest of its
+// publication time and of its embargo.
+func (n *News) ReleaseAt() time.Time {
+	if n.EmbargoUntil.After(n.PublishAt) {
+		return n.EmbargoUntil
+	}
+	return n.PublishAt
+}
+
+// Embargoed reports whether the news is still under embargo at the time.
+func (n *News) Embargoed(now time.Time) bool {
+	return now.Before(n.EmbargoUntil)
+}
+
+// scheduleState returns the state a news moving to the state is left in at
+// the time: a published news is scheduled until its release time, and a
+// scheduled news is published once it is reached.
+func scheduleState(state State, news *News, now time.Time) State {
+	switch state {
+	case StatePublished, StateScheduled:
+		if now.Before(news.ReleaseAt()) {
+			return StateScheduled
+		}
+		return StatePublished
+	case StateDraft, StateInReview, StateArchived:
+	}
+	return state
+}
+
+// byRelease orders the news by release time and then by id.
+func byRelease(a, b *News) bool {
+	if c := a.ReleaseAt().Compare(b.ReleaseAt()); c != 0 {
+		return c < 0
+	}
+	return slices.Compare(a.ID[:], b.ID[:]) < 0
+}
+
+// Transition moves the news to the next state of the workflow. It fails with
+// ErrConflict when the transition is not allowed from the state of the news,
+// or when the news is not at the expected version unless the version is zero.
+func (s *Store) Transition(ctx context.Context, id uuid.UUID, transition Transition, version int64) (*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%s news: %w", transition, err)
+	}
+	step, ok := workflow[transition]
+	if !ok {
+		return nil, fmt.Errorf("unknown %s", transition)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	news, ok := s.news[id]
+	if !ok || !news.DeletedAt.IsZero() {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	if err := checkVersion(news, version); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(step.from, news.State) {
+		return nil, fmt.Errorf("%w: cannot %s news %s, it is %s", ErrConflict, transition, id, news.State)
+	}
+	movedNews := *news
+	movedNews.UpdatedAt = time.Now().UTC()
+	movedNews.State = scheduleState(step.to, &movedNews, movedNews.UpdatedAt)
+	movedNews.Version++
+	movedNews.Editor = editorOf(ctx, news.Editor)
+	if err := s.commit(Write{News: &movedNews}); err != nil {
+		return nil, err
+	}
+	return &movedNews, nil
+}
+
+// Release publishes the scheduled news whose release time is reached at the
+// time, in the order of their release time.
+func (s *Store) Release(ctx context.Context, now time.Time) ([]*News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("release news: %w", err)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var due []*News
+	s.scheduled.Ascend(func(news *News) bool {
+		if news.ReleaseAt().After(now) {
+			return false
+		}
+		due = append(due, news)
+		return true
+	})
+
+	released := make([]*News, 0, len(due))
+	writes := make([]Write, 0, len(due))
+	for _, news := range due {
+		releasedNews := *news
+		releasedNews.State = StatePublished
+		releasedNews.UpdatedAt = time.Now().UTC()
+		releasedNews.Version++
+		released = append(released, &releasedNews)
+		writes = append(writes, Write{News: &releasedNews})
+	}
+	if err := s.commit(writes...); err != nil {
+		return nil, err
+	}
+	return released, nil
+}
+
+// NextRelease returns the release time of the next scheduled news, false when
+// no news is scheduled.
+func (s *Store) NextRelease() (time.Time, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	news, ok := s.scheduled.Min()
+	if !ok {
+		return time.Time{}, false
+	}
+	return news.ReleaseAt(), true
+}
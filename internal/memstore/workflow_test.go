@@ -0,0 +1,152 @@
+package memstore_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/google/uuid"
+)
+
+// newsIn returns a store holding a news walked through the transitions.
+func newsIn(t *testing.T, publishAt time.Time, transitions ...memstore.Transition) (*memstore.Store, *memstore.News) {
+	t.Helper()
+
+	ctx := t.Context()
+	store := memstore.New()
+	news, err := store.Create(ctx, &memstore.News{Title: "title", PublishAt: publishAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, transition := range transitions {
+		if news, err = store.Transition(ctx, news.ID, transition, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return store, news
+}
+
+func TestStoreTransition(t *testing.T) {
+	var (
+		draft     []memstore.Transition
+		inReview  = []memstore.Transition{memstore.TransitionSubmit}
+		published = []memstore.Transition{memstore.TransitionPublish}
+		archived  = []memstore.Transition{memstore.TransitionPublish, memstore.TransitionArchive}
+	)
+	later := time.Now().Add(time.Hour)
+
+	for _, tc := range []struct {
+		name       string
+		publishAt  time.Time
+		from       []memstore.Transition
+		transition memstore.Transition
+		wantState  memstore.State
+		wantErr    error
+	}{
+		{"submit draft", time.Time{}, draft, memstore.TransitionSubmit, memstore.StateInReview, nil},
+		{"approve draft", time.Time{}, draft, memstore.TransitionApprove, 0, memstore.ErrConflict},
+		{"reject draft", time.Time{}, draft, memstore.TransitionReject, 0, memstore.ErrConflict},
+		{"publish draft", time.Time{}, draft, memstore.TransitionPublish, memstore.StatePublished, nil},
+		{"archive draft", time.Time{}, draft, memstore.TransitionArchive, 0, memstore.ErrConflict},
+
+		{"submit in review", time.Time{}, inReview, memstore.TransitionSubmit, 0, memstore.ErrConflict},
+		{"approve in review", time.Time{}, inReview, memstore.TransitionApprove, memstore.StatePublished, nil},
+		{"reject in review", time.Time{}, inReview, memstore.TransitionReject, memstore.StateDraft, nil},
+		{"publish in review", time.Time{}, inReview, memstore.TransitionPublish, 0, memstore.ErrConflict},
+		{"archive in review", time.Time{}, inReview, memstore.TransitionArchive, 0, memstore.ErrConflict},
+
+		{"submit published", time.Time{}, published, memstore.TransitionSubmit, 0, memstore.ErrConflict},
+		{"approve published", time.Time{}, published, memstore.TransitionApprove, 0, memstore.ErrConflict},
+		{"reject published", time.Time{}, published, memstore.TransitionReject, 0, memstore.ErrConflict},
+		{"publish published", time.Time{}, published, memstore.TransitionPublish, 0, memstore.ErrConflict},
+		{"archive published", time.Time{}, published, memstore.TransitionArchive, memstore.StateArchived, nil},
+
+		{"submit archived", time.Time{}, archived, memstore.TransitionSubmit, 0, memstore.ErrConflict},
+		{"approve archived", time.Time{}, archived, memstore.TransitionApprove, 0, memstore.ErrConflict},
+		{"reject archived", time.Time{}, archived, memstore.TransitionReject, 0, memstore.ErrConflict},
+		{"publish archived", time.Time{}, archived, memstore.TransitionPublish, memstore.StatePublished, nil},
+		{"archive archived", time.Time{}, archived, memstore.TransitionArchive, 0, memstore.ErrConflict},
+
+		{"approve ahead of release", later, inReview, memstore.TransitionApprove, memstore.StateScheduled, nil},
+		{"publish ahead of release", later, draft, memstore.TransitionPublish, memstore.StateScheduled, nil},
+		{"submit scheduled", later, published, memstore.TransitionSubmit, 0, memstore.ErrConflict},
+		{"publish scheduled", later, published, memstore.TransitionPublish, 0, memstore.ErrConflict},
+		{"archive scheduled", later, published, memstore.TransitionArchive, memstore.StateArchived, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			store, news := newsIn(t, tc.publishAt, tc.from...)
+
+			moved, err := store.Transition(t.Context(), news.ID, tc.transition, news.Version)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Transition() error = %v, want %v", err, tc.wantErr)
+			}
+			if err != nil {
+				// A refused transition leaves the news as it was.
+				got, getErr := store.Get(t.Context(), news.ID)
+				if getErr != nil {
+					t.Fatal(getErr)
+				}
+				if got.State != news.State || got.Version != news.Version {
+					t.Errorf("Transition() left news %s at version %d, want %s at version %d", got.State, got.Version, news.State, news.Version)
+				}
+				return
+			}
+			if moved.State != tc.wantState {
+				t.Errorf("Transition() state = %s, want %s", moved.State, tc.wantState)
+			}
+			if moved.Version != news.Version+1 {
+				t.Errorf("Transition() version = %d, want %d", moved.Version, news.Version+1)
+			}
+		})
+	}
+}
+
+func TestStoreTransitionChecks(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		id      func(news *memstore.News) uuid.UUID
+		version func(news *memstore.News) int64
+		delete  bool
+		wantErr error
+	}{
+		{
+			name:    "any version",
+			id:      func(news *memstore.News) uuid.UUID { return news.ID },
+			version: func(*memstore.News) int64 { return 0 },
+		},
+		{
+			name:    "stale version",
+			id:      func(news *memstore.News) uuid.UUID { return news.ID },
+			version: func(news *memstore.News) int64 { return news.Version - 1 },
+			wantErr: memstore.ErrConflict,
+		},
+		{
+			name:    "unknown news",
+			id:      func(*memstore.News) uuid.UUID { return uuid.New() },
+			version: func(*memstore.News) int64 { return 0 },
+			wantErr: memstore.ErrNotFound,
+		},
+		{
+			name:    "deleted news",
+			id:      func(news *memstore.News) uuid.UUID { return news.ID },
+			version: func(*memstore.News) int64 { return 0 },
+			delete:  true,
+			wantErr: memstore.ErrNotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			store, news := newsIn(t, time.Time{}, memstore.TransitionSubmit)
+			if tc.delete {
+				if _, err := store.Delete(t.Context(), news.ID, 0); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, err := store.Transition(t.Context(), tc.id(news), memstore.TransitionApprove, tc.version(news))
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("Transition() error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Package metrics exposes the calls of the gRPC server and the size of the
+// store as Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+)
+
+// namespace of the metrics.
+const namespace = "news"
+
+// Metrics of the calls.
+type Metrics struct {
+	handled       *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+	activeStreams *prometheus.GaugeVec
+	received      *prometheus.CounterVec
+	sent          *prometheus.CounterVec
+}
+
+// New returns the metrics of the calls, registered to the registerer.
+func New(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rpc_handled_total",
+			Help:      "Calls completed by the server, by method and status code.",
+		}, []string{"method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "rpc_duration_seconds",
+			Help:      "Duration of the calls until they are completed, by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		activeStreams: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "rpc_active_streams",
+			Help:      "Streaming calls in progress, by method.",
+		}, []string{"method"}),
+		received: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rpc_stream_messages_received_total",
+			Help:      "Messages received by the streaming calls, by method.",
+		}, []string{"method"}),
+		sent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rpc_stream_messages_sent_total",
+			Help:      "Messages sent by the streaming calls, by method.",
+		}, []string{"method"}),
+	}
+	for _, c := range []prometheus.Collector{m.handled, m.duration, m.activeStreams, m.received, m.sent} {
+		if err := reg.Register(c); err != nil {
+			return nil, fmt.Errorf("register rpc metrics: %w", err)
+		}
+	}
+	return m, nil
+}
+
+// UnaryServerInterceptor measures the unary calls.
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+		m.observe(info.FullMethod, start, err)
+		return res, err
+	}
+}
+
+// StreamServerInterceptor measures the streaming calls along with their
+// messages.
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		active := m.activeStreams.WithLabelValues(info.FullMethod)
+		active.Inc()
+		defer active.Dec()
+
+		err := handler(srv, &serverStream{
+			ServerStream: stream,
+			received:     m.received.WithLabelValues(info.FullMethod),
+			sent:         m.sent.WithLabelValues(info.FullMethod),
+		})
+		m.observe(info.FullMethod, start, err)
+		return err
+	}
+}
+
+// observe the completion of a call of the method.
+func (m *Metrics) observe(method string, start time.Time, err error) {
+	m.handled.WithLabelValues(method, status.Code(err).String()).Inc()
+	m.duration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// serverStream counts the messages of the stream.
+type serverStream struct {
+	grpc.ServerStream
+	received prometheus.Counter
+	sent     prometheus.Counter
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received.Inc()
+	}
+	return err //nolint:wrapcheck // io.EOF ends the stream and must be kept.
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent.Inc()
+	}
+	return err //nolint:wrapcheck // The status of the stream is kept.
+}
+
+// StatsReader reads the stats of a store.
+type StatsReader interface {
+	Stats() memstore.Stats
+}
+
+// RegisterStore registers the gauges of the number of live and soft deleted
+// news of the store, read on every scrape.
+func RegisterStore(reg prometheus.Registerer, store StatsReader) error {
+	for _, c := range []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "store_live_news",
+			Help:      "Live news in the store, whatever their state.",
+		}, func() float64 { return float64(store.Stats().Live) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "store_deleted_news",
+			Help:      "Soft deleted news in the trash of the store.",
+		}, func() float64 { return float64(store.Stats().Deleted) }),
+	} {
+		if err := reg.Register(c); err != nil {
+			return fmt.Errorf("register store metrics: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/codeandlearn1991/news-grpc/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const method = "/news.v1.NewsService/Get"
+
+func TestNewRegistersOnce(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	if _, err := metrics.New(reg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := metrics.New(reg); err == nil {
+		t.Error("New() of a registry holding the metrics error = nil, want one")
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	m, err := metrics.New(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	interceptor := m.UnaryServerInterceptor()
+	for _, callErr := range []error{nil, nil, status.Error(codes.NotFound, "news not found")} {
+		if _, err = interceptor(t.Context(), nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(context.Context, any) (any, error) { return nil, callErr }); !errors.Is(err, callErr) {
+			t.Fatalf("interceptor error = %v, want %v", err, callErr)
+		}
+	}
+
+	want := `
+# HELP news_rpc_handled_total Calls completed by the server, by method and status code.
+# TYPE news_rpc_handled_total counter
+news_rpc_handled_total{code="NotFound",method="/news.v1.NewsService/Get"} 1
+news_rpc_handled_total{code="OK",method="/news.v1.NewsService/Get"} 2
+`
+	if err = testutil.GatherAndCompare(reg, strings.NewReader(want), "news_rpc_handled_total"); err != nil {
+		t.Error(err)
+	}
+	if got := testutil.CollectAndCount(reg, "news_rpc_duration_seconds"); got != 1 {
+		t.Errorf("news_rpc_duration_seconds series = %d, want 1", got)
+	}
+}
+
+// serverStream receives the requests.
+type serverStream struct {
+	grpc.ServerStream
+	reqs int
+}
+
+func (s *serverStream) RecvMsg(any) error {
+	if s.reqs == 0 {
+		return io.EOF
+	}
+	s.reqs--
+	return nil
+}
+
+func (s *serverStream) SendMsg(any) error { return nil }
+
+func TestStreamServerInterceptor(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	m, err := metrics.New(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const streamMethod = "/news.v1.NewsService/DeletedNews"
+	active := func() float64 {
+		t.Helper()
+
+		families, gatherErr := reg.Gather()
+		if gatherErr != nil {
+			t.Fatal(gatherErr)
+		}
+		for _, family := range families {
+			if family.GetName() == "news_rpc_active_streams" {
+				return family.GetMetric()[0].GetGauge().GetValue()
+			}
+		}
+		return 0
+	}
+
+	err = m.StreamServerInterceptor()(nil, &serverStream{reqs: 2}, &grpc.StreamServerInfo{FullMethod: streamMethod},
+		func(_ any, ss grpc.ServerStream) error {
+			if got := active(); got != 1 {
+				t.Errorf("news_rpc_active_streams during the call = %v, want 1", got)
+			}
+			for {
+				if recvErr := ss.RecvMsg(nil); errors.Is(recvErr, io.EOF) {
+					return status.Error(codes.Canceled, "canceled")
+				}
+				for range 3 {
+					if sendErr := ss.SendMsg(nil); sendErr != nil {
+						return status.Errorf(codes.Internal, "send: %v", sendErr)
+					}
+				}
+			}
+		})
+	if status.Code(err) != codes.Canceled {
+		t.Fatalf("interceptor error = %v, want %s", err, codes.Canceled)
+	}
+
+	want := `
+# HELP news_rpc_active_streams Streaming calls in progress, by method.
+# TYPE news_rpc_active_streams gauge
+news_rpc_active_streams{method="/news.v1.NewsService/DeletedNews"} 0
+# HELP news_rpc_handled_total Calls completed by the server, by method and status code.
+# TYPE news_rpc_handled_total counter
+news_rpc_handled_total{code="Canceled",method="/news.v1.NewsService/DeletedNews"} 1
+# HELP news_rpc_stream_messages_received_total Messages received by the streaming calls, by method.
+# TYPE news_rpc_stream_messages_received_total counter
+news_rpc_stream_messages_received_total{method="/news.v1.NewsService/DeletedNews"} 2
+# HELP news_rpc_stream_messages_sent_total Messages sent by the streaming calls, by method.
+# TYPE news_rpc_stream_messages_sent_total counter
+news_rpc_stream_messages_sent_total{method="/news.v1.NewsService/DeletedNews"} 6
+`
+	if err = testutil.GatherAndCompare(reg, strings.NewReader(want),
+		"news_rpc_active_streams",
+		"news_rpc_handled_total",
+		"news_rpc_stream_messages_received_total",
+		"news_rpc_stream_messages_sent_total",
+	); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRegisterStore(t *testing.T) {
+	ctx := t.Context()
+	store := memstore.New()
+	reg := prometheus.NewPedanticRegistry()
+	if err := metrics.RegisterStore(reg, store); err != nil {
+		t.Fatal(err)
+	}
+
+	// The gauges are read on every scrape, the news written after the
+	// registration are counted.
+	var news *memstore.News
+	for _, title := range []string{"first", "second", "third"} {
+		var err error
+		if news, err = store.Create(ctx, &memstore.News{Title: title}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := store.Delete(ctx, news.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	want := `
+# HELP news_store_deleted_news Soft deleted news in the trash of the store.
+# TYPE news_store_deleted_news gauge
+news_store_deleted_news 1
+# HELP news_store_live_news Live news in the store, whatever their state.
+# TYPE news_store_live_news gauge
+news_store_live_news 2
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+	if err := metrics.RegisterStore(reg, store); err == nil {
+		t.Error("RegisterStore() of a registry holding the gauges error = nil, want one")
+	}
+}
@@ -0,0 +1,41 @@
+// Package retention permanently purges the news that stayed soft deleted for
+// longer than a retention period.
+package retention
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+)
+
+// Purger to purge the news soft deleted before a time.
+type Purger interface {
+	PurgeDeleted(ctx context.Context, before time.Time) ([]*memstore.News, error)
+}
+
+// Run purges the news soft deleted for longer than the period, right away and
+// then every interval, until the context is done. Failed purges are logged
+// and retried on the next run.
+func Run(ctx context.Context, purger Purger, period, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		purged, err := purger.PurgeDeleted(ctx, time.Now().Add(-period))
+		switch {
+		case errors.Is(err, context.Canceled):
+		case err != nil:
+			log.Printf("retention: %v", err)
+		case len(purged) > 0:
+			log.Printf("retention: purged %d news deleted for more than %s", len(purged), period)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package retention_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/codeandlearn1991/news-grpc/internal/retention"
+)
+
+// purger records the times it purges before, failing the first purge.
+type purger struct {
+	lock    sync.Mutex
+	befores []time.Time
+	calls   chan struct{}
+}
+
+func (p *purger) PurgeDeleted(_ context.Context, before time.Time) ([]*memstore.News, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.befores = append(p.befores, before)
+	select {
+	case p.calls <- struct{}{}:
+	default:
+	}
+	if len(p.befores) == 1 {
+		return nil, errors.New("store unavailable")
+	}
+	return []*memstore.News{{Title: "purged"}}, nil
+}
+
+func TestRun(t *testing.T) {
+	const period = time.Hour
+	p := &purger{calls: make(chan struct{}, 3)}
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	start := time.Now()
+	go func() {
+		defer close(done)
+		retention.Run(ctx, p, period, time.Millisecond)
+	}()
+
+	// The failed purge is retried on the next run.
+	for range 3 {
+		select {
+		case <-p.calls:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Run() did not purge")
+		}
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return once the context was done")
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for i, before := range p.befores {
+		if earliest, latest := start.Add(-period), time.Now().Add(-period); before.Before(earliest) || before.After(latest) {
+			t.Errorf("purge %d before %v, want between %v and %v", i, before, earliest, latest)
+		}
+	}
+}
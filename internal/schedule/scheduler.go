@@ -0,0 +1,81 @@
+// Package schedule publishes the scheduled news once their release time is
+// reached.
+package schedule
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"time"
+
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+)
+
+// retryDelay after a failed release.
+const retryDelay = 10 * time.Second
+
+// Releaser to publish the scheduled news.
+type Releaser interface {
+	Release(ctx context.Context, now time.Time) ([]*memstore.News, error)
+	NextRelease() (time.Time, bool)
+}
+
+// Scheduler publishes the scheduled news at their release time. It is
+// registered as an indexer of the store to learn about newly scheduled news.
+type Scheduler struct {
+	wake chan struct{}
+}
+
+// NewScheduler constructor for the scheduler.
+func NewScheduler() *Scheduler {
+	return &Scheduler{wake: make(chan struct{}, 1)}
+}
+
+// Index wakes the scheduler up when the news is scheduled, as it may be due
+// before the next release. It is called by the store under its write lock and
+// never blocks.
+func (s *Scheduler) Index(news *memstore.News) {
+	if news.State != memstore.StateScheduled {
+		return
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run publishes the scheduled news as their release time is reached until
+// the context is done. The news due while the scheduler was not running are
+// published right away.
+func (s *Scheduler) Run(ctx context.Context, releaser Releaser) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-s.wake:
+		}
+
+		delay := retryDelay
+		released, err := releaser.Release(ctx, time.Now())
+		switch {
+		case errors.Is(err, context.Canceled):
+			return
+		case err != nil:
+			log.Printf("schedule: %v", err)
+		default:
+			if len(released) > 0 {
+				log.Printf("schedule: published %d news", len(released))
+			}
+			// Without scheduled news, the scheduler sleeps until woken up.
+			delay = math.MaxInt64
+			if next, ok := releaser.NextRelease(); ok {
+				delay = time.Until(next)
+			}
+		}
+		timer.Reset(delay)
+	}
+}
@@ -0,0 +1,100 @@
+package schedule_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codeandlearn1991/news-grpc/internal/diskstore"
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/codeandlearn1991/news-grpc/internal/schedule"
+	"github.com/codeandlearn1991/news-grpc/internal/watch"
+)
+
+// scheduleNews in a disk store of the directory, closed once done.
+func scheduleNews(t *testing.T, dir string, publishAt time.Time) *memstore.News {
+	t.Helper()
+
+	ctx := t.Context()
+	store, err := diskstore.Open(diskstore.Config{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	news, err := store.Create(ctx, &memstore.News{Title: "title", PublishAt: publishAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if news, err = store.Transition(ctx, news.ID, memstore.TransitionPublish, 0); err != nil {
+		t.Fatal(err)
+	}
+	if news.State != memstore.StateScheduled {
+		t.Fatalf("Transition() state = %s, want %s", news.State, memstore.StateScheduled)
+	}
+	if err = store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return news
+}
+
+func TestSchedulerCatchesUpAfterRestart(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+	publishAt := time.Now().Add(50 * time.Millisecond)
+	news := scheduleNews(t, dir, publishAt)
+
+	// The release time passes while the server is down.
+	time.Sleep(time.Until(publishAt))
+
+	hub := watch.NewHub()
+	defer hub.Close()
+	scheduler := schedule.NewScheduler()
+	store, err := diskstore.Open(diskstore.Config{Dir: dir}, memstore.WithIndexer(hub), memstore.WithIndexer(scheduler))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	sub, err := hub.Subscribe(watch.Filter{}, news.Seq, watch.PolicyBuffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scheduler.Run(runCtx, store)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	timeout := make(chan struct{})
+	timer := time.AfterFunc(5*time.Second, func() { close(timeout) })
+	defer timer.Stop()
+	event, err := sub.Next(timeout)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if event.Type != watch.EventUpdated || event.News.ID != news.ID || event.News.State != memstore.StatePublished {
+		t.Errorf("Next() event = %v of news %s in state %s, want %v of news %s in state %s",
+			event.Type, event.News.ID, event.News.State, watch.EventUpdated, news.ID, memstore.StatePublished)
+	}
+
+	released, err := store.Get(ctx, news.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if released.State != memstore.StatePublished {
+		t.Errorf("Get() state = %s, want %s", released.State, memstore.StatePublished)
+	}
+	changes, err := store.Changes(ctx, news.Seq, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].ID != news.ID || changes[0].State != memstore.StatePublished {
+		t.Errorf("Changes() = %v, want the release of news %s", changes, news.ID)
+	}
+}
@@ -0,0 +1,256 @@
+package search
+
+import (
+	"html"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/google/uuid"
+)
+
+// BM25 ranking parameters.
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// Weights of the fields, a term found in the title counts more than in the content.
+const (
+	titleWeight   = 3
+	summaryWeight = 2
+	contentWeight = 1
+)
+
+// Snippet sizes in words.
+const (
+	snippetLead  = 5
+	snippetWords = 25
+)
+
+// Highlight markers wrapped around the matching words of a snippet.
+const (
+	HighlightStart = "<em>"
+	HighlightEnd   = "</em>"
+)
+
+// Snippet of a news field with the matching words highlighted.
+type Snippet struct {
+	// Field of the news, one of title, summary or content.
+	Field string
+	// Text of the snippet, HTML escaped apart from the highlight markers.
+	Text string
+}
+
+// Hit is a news matching a search query.
+type Hit struct {
+	// News matching the query.
+	News *memstore.News
+	// Score of the news for the query, higher is more relevant.
+	Score float64
+	// Snippets of the fields matching the query.
+	Snippets []Snippet
+}
+
+type document struct {
+	news *memstore.News
+	// length is the weighted number of terms of the document.
+	length float64
+	// frequencies is the weighted frequency of each term of the document.
+	frequencies map[string]float64
+}
+
+// Index is an in-memory inverted index over the title, summary and content
+// of the news.
+type Index struct {
+	lock        sync.RWMutex
+	docs        map[uuid.UUID]*document
+	postings    map[string]map[uuid.UUID]float64
+	totalLength float64
+}
+
+// NewIndex constructor for the index.
+func NewIndex() *Index {
+	return &Index{
+		lock:     sync.RWMutex{},
+		docs:     make(map[uuid.UUID]*document),
+		postings: make(map[string]map[uuid.UUID]float64),
+	}
+}
+
+// Index adds or replaces the news in the index. Soft deleted and unpublished
+// news are removed from the index.
+func (i *Index) Index(news *memstore.News) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.remove(news.ID)
+	if !news.DeletedAt.IsZero() || news.State != memstore.StatePublished {
+		return
+	}
+
+	doc := &document{news: news, frequencies: make(map[string]float64)}
+	for _, field := range []struct {
+		text   string
+		weight float64
+	}{
+		{news.Title, titleWeight},
+		{news.Summary, summaryWeight},
+		{news.Content, contentWeight},
+	} {
+		for _, t := range tokenize(field.text) {
+			doc.frequencies[t.term] += field.weight
+			doc.length += field.weight
+		}
+	}
+
+	for term, freq := range doc.frequencies {
+		postings, ok := i.postings[term]
+		if !ok {
+			postings = make(map[uuid.UUID]float64)
+			i.postings[term] = postings
+		}
+		postings[news.ID] = freq
+	}
+	i.docs[news.ID] = doc
+	i.totalLength += doc.length
+}
+
+func (i *Index) remove(id uuid.UUID) {
+	doc, ok := i.docs[id]
+	if !ok {
+		return
+	}
+	for term := range doc.frequencies {
+		delete(i.postings[term], id)
+		if len(i.postings[term]) == 0 {
+			delete(i.postings, term)
+		}
+	}
+	delete(i.docs, id)
+	i.totalLength -= doc.length
+}
+
+// Search the news matching any term of the query ranked by BM25. It returns
+// at most limit hits starting at offset along with the total number of hits.
+func (i *Index) Search(query string, offset, limit int) (hits []Hit, total int) {
+	queryTerms := terms(query)
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	if len(i.docs) == 0 || len(queryTerms) == 0 {
+		return []Hit{}, 0
+	}
+
+	n := float64(len(i.docs))
+	avgLength := i.totalLength / n
+	scores := make(map[uuid.UUID]float64)
+	for _, term := range queryTerms {
+		postings := i.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, tf := range postings {
+			norm := k1 * (1 - b + b*i.docs[id].length/avgLength)
+			scores[id] += idf * tf * (k1 + 1) / (tf + norm)
+		}
+	}
+
+	hits = make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{News: i.docs[id].news, Score: score})
+	}
+	slices.SortFunc(hits, compareHits)
+
+	total = len(hits)
+	if offset >= total {
+		return []Hit{}, total
+	}
+	hits = hits[offset:min(offset+limit, total)]
+
+	termSet := make(map[string]struct{}, len(queryTerms))
+	for _, term := range queryTerms {
+		termSet[term] = struct{}{}
+	}
+	for idx := range hits {
+		hits[idx].Snippets = snippets(hits[idx].News, termSet)
+	}
+
+	return hits, total
+}
+
+// compareHits orders hits by descending score, ties are broken by the most
+// recent news first and then by id to keep pagination stable.
+func compareHits(a, b Hit) int {
+	switch {
+	case a.Score > b.Score:
+		return -1
+	case a.Score < b.Score:
+		return 1
+	}
+	if c := b.News.CreatedAt.Compare(a.News.CreatedAt); c != 0 {
+		return c
+	}
+	return strings.Compare(a.News.ID.String(), b.News.ID.String())
+}
+
+func snippets(news *memstore.News, termSet map[string]struct{}) []Snippet {
+	result := make([]Snippet, 0)
+	for _, field := range []struct {
+		name string
+		text string
+	}{
+		{"title", news.Title},
+		{"summary", news.Summary},
+		{"content", news.Content},
+	} {
+		if text, ok := highlight(field.text, termSet); ok {
+			result = append(result, Snippet{Field: field.name, Text: text})
+		}
+	}
+	return result
+}
+
+// highlight returns a window of the text around the first matching word with
+// every matching word of the window wrapped in highlight markers. The text is
+// HTML escaped so that only the markers are markup.
+func highlight(text string, termSet map[string]struct{}) (string, bool) {
+	tokens := tokenize(text)
+	first := slices.IndexFunc(tokens, func(t token) bool {
+		_, ok := termSet[t.term]
+		return ok
+	})
+	if first < 0 {
+		return "", false
+	}
+
+	from := max(0, first-snippetLead)
+	to := min(len(tokens), from+snippetWords)
+
+	var sb strings.Builder
+	if from > 0 {
+		sb.WriteString("…")
+	}
+	pos := tokens[from].start
+	for _, t := range tokens[from:to] {
+		if _, ok := termSet[t.term]; !ok {
+			continue
+		}
+		sb.WriteString(html.EscapeString(text[pos:t.start]))
+		sb.WriteString(HighlightStart)
+		sb.WriteString(html.EscapeString(text[t.start:t.end]))
+		sb.WriteString(HighlightEnd)
+		pos = t.end
+	}
+	sb.WriteString(html.EscapeString(text[pos:tokens[to-1].end]))
+	if to < len(tokens) {
+		sb.WriteString("…")
+	}
+
+	return sb.String(), true
+}
@@ -0,0 +1,164 @@
+package search_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/codeandlearn1991/news-grpc/internal/search"
+	"github.com/google/uuid"
+)
+
+// published returns a published news of the texts.
+func published(title, summary, content string) *memstore.News {
+	return &memstore.News{
+		ID:        uuid.New(),
+		Title:     title,
+		Summary:   summary,
+		Content:   content,
+		State:     memstore.StatePublished,
+		CreatedAt: time.Now(),
+	}
+}
+
+// titlesOf the news of the hits.
+func titlesOf(hits []search.Hit) []string {
+	titles := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		titles = append(titles, hit.News.Title)
+	}
+	return titles
+}
+
+func TestIndexSearchRanking(t *testing.T) {
+	index := search.NewIndex()
+	for _, news := range []*memstore.News{
+		published("In the content", "nothing to see", "the election is held today"),
+		published("Election day", "nothing to see", "polls open at eight"),
+		published("In the summary", "the election is held today", "polls open at eight"),
+		published("Twice in the content", "nothing to see", "election after election"),
+		published("Unrelated", "nothing to see", "the weather is fine"),
+	} {
+		index.Index(news)
+	}
+
+	hits, total := index.Search("election", 0, 10)
+	// The title weighs more than the summary, which weighs more than the
+	// content, and a term found twice more than once.
+	wantTitles := []string{"Election day", "In the summary", "Twice in the content", "In the content"}
+	if got := titlesOf(hits); !slices.Equal(got, wantTitles) {
+		t.Errorf("Search() titles = %q, want %q", got, wantTitles)
+	}
+	if total != len(wantTitles) {
+		t.Errorf("Search() total = %d, want %d", total, len(wantTitles))
+	}
+	for i := 1; i < len(hits); i++ {
+		if hits[i].Score > hits[i-1].Score {
+			t.Errorf("Search() score %d = %v above score %d = %v", i, hits[i].Score, i-1, hits[i-1].Score)
+		}
+	}
+
+	page, _ := index.Search("election", 1, 2)
+	if got := titlesOf(page); !slices.Equal(got, wantTitles[1:3]) {
+		t.Errorf("Search() page titles = %q, want %q", got, wantTitles[1:3])
+	}
+}
+
+func TestIndexSearchStems(t *testing.T) {
+	index := search.NewIndex()
+	index.Index(published("Run for the city", "a summary", "some content"))
+	index.Index(published("Connections", "a summary", "some content"))
+
+	for _, tc := range []struct {
+		query      string
+		wantTitles []string
+	}{
+		{"running", []string{"Run for the city"}},
+		{"RUNS", []string{"Run for the city"}},
+		{"connected", []string{"Connections"}},
+		{"the", []string{}},
+		{"walking", []string{}},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			hits, _ := index.Search(tc.query, 0, 10)
+			if got := titlesOf(hits); !slices.Equal(got, tc.wantTitles) {
+				t.Errorf("Search(%q) titles = %q, want %q", tc.query, got, tc.wantTitles)
+			}
+		})
+	}
+}
+
+func TestIndexRemovesUnreadableNews(t *testing.T) {
+	index := search.NewIndex()
+	news := published("Election day", "a summary", "some content")
+	index.Index(news)
+
+	for _, tc := range []struct {
+		name   string
+		change func(news *memstore.News)
+	}{
+		{"archived", func(news *memstore.News) { news.State = memstore.StateArchived }},
+		{"deleted", func(news *memstore.News) { news.DeletedAt = time.Now() }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			changed := *news
+			tc.change(&changed)
+			index.Index(&changed)
+			if hits, total := index.Search("election", 0, 10); total != 0 {
+				t.Errorf("Search() titles = %q, want none", titlesOf(hits))
+			}
+			index.Index(news)
+		})
+	}
+}
+
+func TestIndexSearchSnippets(t *testing.T) {
+	index := search.NewIndex()
+	index.Index(published(
+		"Running <b>late</b>",
+		"A summary",
+		"<script>alert('run')</script> & the run goes on",
+	))
+
+	hits, _ := index.Search("run", 0, 10)
+	if len(hits) != 1 {
+		t.Fatalf("Search() hits = %d, want 1", len(hits))
+	}
+	want := []search.Snippet{
+		{Field: "title", Text: "<em>Running</em> &lt;b&gt;late&lt;/b"},
+		{Field: "content", Text: "script&gt;alert(&#39;<em>run</em>&#39;)&lt;/script&gt; &amp; the <em>run</em> goes"},
+	}
+	if got := hits[0].Snippets; !slices.Equal(got, want) {
+		t.Errorf("Search() snippets = %q, want %q", got, want)
+	}
+	for _, snippet := range hits[0].Snippets {
+		markup := strings.NewReplacer(search.HighlightStart, "", search.HighlightEnd, "").Replace(snippet.Text)
+		if strings.ContainsAny(markup, "<>") {
+			t.Errorf("snippet of %s %q holds markup other than the highlights", snippet.Field, snippet.Text)
+		}
+	}
+}
+
+func TestStem(t *testing.T) {
+	for _, tc := range []struct {
+		word string
+		want string
+	}{
+		{"run", "run"},
+		{"running", "run"},
+		{"runs", "run"},
+		{"caresses", "caress"},
+		{"ponies", "poni"},
+		{"hopping", "hop"},
+		{"relational", "relat"},
+		{"connection", "connect"},
+		{"generalizations", "gener"},
+		{"café", "café"},
+	} {
+		if got := search.Stem(tc.word); got != tc.want {
+			t.Errorf("Stem(%q) = %q, want %q", tc.word, got, tc.want)
+		}
+	}
+}
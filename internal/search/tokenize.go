@@ -0,0 +1,70 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopWords are too common to carry any meaning and are never indexed.
+var stopWords = map[string]struct{}{
+	"a": {}, "an": {}, "and": {}, "are": {}, "as": {}, "at": {}, "be": {}, "but": {},
+	"by": {}, "for": {}, "if": {}, "in": {}, "into": {}, "is": {}, "it": {}, "no": {},
+	"not": {}, "of": {}, "on": {}, "or": {}, "such": {}, "that": {}, "the": {}, "their": {},
+	"then": {}, "there": {}, "these": {}, "they": {}, "this": {}, "to": {}, "was": {},
+	"will": {}, "with": {},
+}
+
+// token is a word found in a text.
+type token struct {
+	// term is the normalized stem of the word.
+	term string
+	// start and end byte offsets of the word in the text.
+	start, end int
+}
+
+// tokenize splits the text into words, drops the stop words and stems the
+// remaining ones.
+func tokenize(text string) []token {
+	tokens := make([]token, 0)
+
+	start := -1
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = appendToken(tokens, text, start, i)
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = appendToken(tokens, text, start, len(text))
+	}
+
+	return tokens
+}
+
+func appendToken(tokens []token, text string, start, end int) []token {
+	word := strings.ToLower(text[start:end])
+	if _, ok := stopWords[word]; ok {
+		return tokens
+	}
+	return append(tokens, token{term: Stem(word), start: start, end: end})
+}
+
+// terms returns the distinct stemmed terms of the text.
+func terms(text string) []string {
+	seen := make(map[string]struct{})
+	result := make([]string, 0)
+	for _, t := range tokenize(text) {
+		if _, ok := seen[t.term]; ok {
+			continue
+		}
+		seen[t.term] = struct{}{}
+		result = append(result, t.term)
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package validation
+
+import (
+	"context"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor validates the requests of the unary calls.
+func UnaryServerInterceptor(validator protovalidate.Validator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := validate(validator, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor validates the request of the server streaming
+// calls, the call fails when it is invalid. The items of client streams are
+// left to the handlers, which report the outcome of every item.
+func StreamServerInterceptor(validator protovalidate.Validator) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if info.IsClientStream {
+			return handler(srv, stream)
+		}
+		return handler(srv, &serverStream{ServerStream: stream, validator: validator})
+	}
+}
+
+// serverStream validates the received messages.
+type serverStream struct {
+	grpc.ServerStream
+	validator protovalidate.Validator
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err //nolint:wrapcheck // The stream errors are status errors.
+	}
+	return validate(s.validator, m)
+}
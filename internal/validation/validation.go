@@ -0,0 +1,64 @@
+// Package validation validates the requests of the RPCs against their
+// protovalidate rules. Invalid requests fail with InvalidArgument and a
+// google.rpc.BadRequest detail holding one violation per failed field.
+package validation
+
+import (
+	"errors"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// InvalidArgument returns the violations as an InvalidArgument status error
+// with a google.rpc.BadRequest detail.
+func InvalidArgument(violations []*errdetails.BadRequest_FieldViolation) error {
+	descriptions := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		descriptions = append(descriptions, violation.GetField()+": "+violation.GetDescription())
+	}
+	st := status.New(codes.InvalidArgument, "validation failed: "+strings.Join(descriptions, "; "))
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		detailed = st
+	}
+	return detailed.Err() //nolint:wrapcheck // Status errors are returned as is.
+}
+
+// Violations returns the violations of the validation error, the fields
+// being the proto paths prefixed with the prefix. The reason is the id of the
+// broken rule.
+func Violations(prefix string, err *protovalidate.ValidationError) []*errdetails.BadRequest_FieldViolation {
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(err.Violations))
+	for _, violation := range err.Violations {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       prefix + protovalidate.FieldPathString(violation.Proto.GetField()),
+			Description: violation.Proto.GetMessage(),
+			Reason:      violation.Proto.GetRuleId(),
+		})
+	}
+	return violations
+}
+
+// validate the message with the validator.
+func validate(validator protovalidate.Validator, m any) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "unsupported message type: %T", m)
+	}
+	err := validator.Validate(msg)
+	var validationErr *protovalidate.ValidationError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &validationErr):
+		return InvalidArgument(Violations("", validationErr))
+	default:
+		// The rules do not compile, the request is not at fault.
+		return status.Errorf(codes.Internal, "validate %s: %v", msg.ProtoReflect().Descriptor().FullName(), err)
+	}
+}
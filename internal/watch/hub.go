@@ -0,0 +1,331 @@
+// Package watch streams the changes of the news to subscribers as they are
+// written to the store.
+package watch
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+)
+
+// Default sizes of the hub.
+const (
+	defaultHistory = 1024
+	defaultQueue   = 256
+	// bufferFactor is how many times the queue a subscriber with PolicyBuffer
+	// can fall behind before it is disconnected.
+	bufferFactor = 64
+)
+
+var (
+	// ErrExpired sequence to resume from is no longer in the history of the
+	// hub, or was never reached. The subscriber has to resync.
+	ErrExpired = errors.New("watch sequence expired")
+	// ErrSlowConsumer subscriber fell too far behind and was disconnected.
+	ErrSlowConsumer = errors.New("watch subscriber too slow")
+	// ErrClosed hub was closed.
+	ErrClosed = errors.New("watch hub closed")
+)
+
+// EventType of a change of a news.
+type EventType int
+
+// Types of the events.
+const (
+	// EventCreated news was created.
+	EventCreated EventType = iota + 1
+	// EventUpdated news was updated.
+	EventUpdated
+	// EventDeleted news was soft deleted.
+	EventDeleted
+	// EventGap events of the subscription were dropped as it fell behind.
+	EventGap
+)
+
+// Event is a change of a news, or a gap marker.
+type Event struct {
+	// Seq is the sequence of the change as assigned by the store. For a gap
+	// marker, it is the sequence of the last dropped change.
+	Seq int64
+	// Type of the event.
+	Type EventType
+	// News as written, nil for a gap marker.
+	News *memstore.News
+	// GapStart is the sequence of the first dropped change of a gap marker.
+	GapStart int64
+}
+
+// Policy for the subscribers that fall behind the changes.
+type Policy int
+
+// Policies of the subscribers.
+const (
+	// PolicyBuffer keeps queuing the changes for the subscriber, up to a much
+	// larger bound than the other policies after which it is disconnected.
+	PolicyBuffer Policy = iota
+	// PolicyDrop drops the changes that do not fit in the queue and reports
+	// them with a gap marker once the subscriber catches up.
+	PolicyDrop
+	// PolicyDisconnect disconnects the subscriber as soon as its queue is full.
+	PolicyDisconnect
+)
+
+// Filter of the changes of a subscription, empty fields match any news.
+type Filter struct {
+	Tag    string
+	Author string
+}
+
+func (f Filter) matches(news *memstore.News) bool {
+	if f.Tag != "" && !slices.Contains(news.Tags, f.Tag) {
+		return false
+	}
+	return f.Author == "" || news.Author == f.Author
+}
+
+// Option to configure the hub.
+type Option func(*Hub)
+
+// WithHistory sets how many changes are kept to resume subscriptions from.
+func WithHistory(size int) Option {
+	return func(h *Hub) {
+		h.historySize = size
+	}
+}
+
+// WithQueue sets how many changes a subscriber can fall behind.
+func WithQueue(size int) Option {
+	return func(h *Hub) {
+		h.queueSize = size
+	}
+}
+
+// Hub fans the changes of the news out to the subscribers, along with the
+// sequence assigned to them by the store. It is registered as an indexer of
+// the store.
+type Hub struct {
+	lock sync.Mutex
+	// seq of the last change.
+	seq int64
+	// history of the last changes, a ring buffer starting at start once full.
+	history     []Event
+	start       int
+	historySize int
+	queueSize   int
+	subs        map[*Subscription]struct{}
+	closed      bool
+}
+
+// NewHub constructor for the hub.
+func NewHub(opts ...Option) *Hub {
+	h := &Hub{
+		lock:        sync.Mutex{},
+		historySize: defaultHistory,
+		queueSize:   defaultQueue,
+		subs:        make(map[*Subscription]struct{}),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Index records the change of the news and delivers it to the subscribers.
+// It is called by the store under its write lock and never blocks.
+func (h *Hub) Index(news *memstore.News) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.seq = news.Seq
+	event := Event{Seq: news.Seq, Type: typeOf(news), News: news}
+	switch {
+	case len(h.history) < h.historySize:
+		h.history = append(h.history, event)
+	case h.historySize > 0:
+		h.history[h.start] = event
+		h.start = (h.start + 1) % len(h.history)
+	}
+
+	for sub := range h.subs {
+		if sub.filter.matches(news) {
+			sub.push(event)
+		}
+	}
+}
+
+func typeOf(news *memstore.News) EventType {
+	switch memstore.ChangeOf(news) {
+	case memstore.ChangeCreated:
+		return EventCreated
+	case memstore.ChangeDeleted:
+		return EventDeleted
+	case memstore.ChangeUpdated:
+	}
+	return EventUpdated
+}
+
+// Subscribe to the changes matching the filter. A zero since subscribes to
+// the changes to come, otherwise the changes after since are delivered first.
+// It fails with ErrExpired when they are no longer in the history.
+func (h *Hub) Subscribe(filter Filter, since int64, policy Policy) (*Subscription, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.closed {
+		return nil, ErrClosed
+	}
+
+	limit := h.queueSize
+	if policy == PolicyBuffer {
+		limit *= bufferFactor
+	}
+	sub := &Subscription{
+		hub:    h,
+		filter: filter,
+		policy: policy,
+		limit:  limit,
+		notify: make(chan struct{}, 1),
+	}
+
+	if since != 0 {
+		oldest := h.seq + 1
+		if len(h.history) > 0 {
+			oldest = h.history[h.start].Seq
+		}
+		if since > h.seq || since < oldest-1 {
+			return nil, fmt.Errorf("%w: %d not in %d..%d", ErrExpired, since, oldest-1, h.seq)
+		}
+		for i := range h.history {
+			event := h.history[(h.start+i)%len(h.history)]
+			if event.Seq > since && filter.matches(event.News) {
+				sub.queue = append(sub.queue, event)
+			}
+		}
+	}
+
+	h.subs[sub] = struct{}{}
+	return sub, nil
+}
+
+// Close the hub and the subscriptions.
+func (h *Hub) Close() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.closed = true
+	for sub := range h.subs {
+		sub.close(ErrClosed)
+	}
+}
+
+// Subscription to the changes of the news.
+type Subscription struct {
+	hub    *Hub
+	filter Filter
+	policy Policy
+	limit  int
+
+	lock  sync.Mutex
+	queue []Event
+	// gap is the marker of the changes dropped since the last delivery.
+	gap    *Event
+	err    error
+	notify chan struct{}
+}
+
+// push queues the event according to the policy, the caller must hold the
+// lock of the hub.
+func (s *Subscription) push(event Event) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return
+	}
+
+	// Once changes were dropped, the following ones are dropped too until the
+	// gap is delivered, so that events are always delivered in order.
+	if s.gap == nil && len(s.queue) < s.limit {
+		s.queue = append(s.queue, event)
+		s.signal()
+		return
+	}
+
+	if s.policy != PolicyDrop {
+		s.fail(fmt.Errorf("%w: %d changes behind", ErrSlowConsumer, len(s.queue)))
+		return
+	}
+	if s.gap == nil {
+		s.gap = &Event{Type: EventGap, GapStart: event.Seq}
+		s.signal()
+	}
+	s.gap.Seq = event.Seq
+}
+
+// Next waits for the next event of the subscription. It fails once the
+// subscription is closed, or when done is closed.
+func (s *Subscription) Next(done <-chan struct{}) (Event, error) {
+	for {
+		s.lock.Lock()
+		switch {
+		case len(s.queue) > 0:
+			event := s.queue[0]
+			s.queue = s.queue[1:]
+			s.lock.Unlock()
+			return event, nil
+		case s.gap != nil:
+			// The gap is delivered once the queue drained, after the changes
+			// preceding it.
+			event := *s.gap
+			s.gap = nil
+			s.lock.Unlock()
+			return event, nil
+		case s.err != nil:
+			err := s.err
+			s.lock.Unlock()
+			return Event{}, err
+		}
+		s.lock.Unlock()
+
+		select {
+		case <-s.notify:
+		case <-done:
+			return Event{}, ErrClosed
+		}
+	}
+}
+
+// Close the subscription.
+func (s *Subscription) Close() {
+	s.hub.lock.Lock()
+	defer s.hub.lock.Unlock()
+	s.close(ErrClosed)
+}
+
+// close removes the subscription from the hub, the caller must hold the lock
+// of the hub.
+func (s *Subscription) close(err error) {
+	delete(s.hub.subs, s)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err == nil {
+		s.err = err
+		s.signal()
+	}
+}
+
+// fail the subscription, the caller must hold its lock and the lock of the
+// hub.
+func (s *Subscription) fail(err error) {
+	delete(s.hub.subs, s)
+	s.err = err
+	s.queue = nil
+	s.signal()
+}
+
+func (s *Subscription) signal() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
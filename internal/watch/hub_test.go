@@ -0,0 +1,230 @@
+package watch_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/codeandlearn1991/news-grpc/internal/memstore"
+	"github.com/codeandlearn1991/news-grpc/internal/watch"
+)
+
+// index the changes with the sequences first to last into the hub, the even
+// ones tagged even and the odd ones odd.
+func index(hub *watch.Hub, first, last int64) {
+	for seq := first; seq <= last; seq++ {
+		tag := "even"
+		if seq%2 == 1 {
+			tag = "odd"
+		}
+		hub.Index(&memstore.News{Seq: seq, Version: 1, Tags: []string{tag}})
+	}
+}
+
+// drain returns the sequences of the events of the subscription until it has
+// none ready, a gap marker being reported as the negated start of the gap
+// followed by its end.
+func drain(t *testing.T, sub *watch.Subscription) ([]int64, error) {
+	t.Helper()
+
+	var seqs []int64
+	for {
+		done := make(chan struct{})
+		timer := time.AfterFunc(10*time.Millisecond, func() { close(done) })
+		event, err := sub.Next(done)
+		timer.Stop()
+		switch {
+		case errors.Is(err, watch.ErrClosed):
+			return seqs, nil
+		case err != nil:
+			return seqs, fmt.Errorf("next event: %w", err)
+		case event.Type == watch.EventGap:
+			seqs = append(seqs, -event.GapStart, event.Seq)
+		default:
+			seqs = append(seqs, event.Seq)
+		}
+	}
+}
+
+func TestSubscriptionPolicies(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		policy   watch.Policy
+		changes  int64
+		wantSeqs []int64
+		wantErr  error
+	}{
+		{
+			name:     "buffer within the queue",
+			policy:   watch.PolicyBuffer,
+			changes:  2,
+			wantSeqs: []int64{1, 2},
+		},
+		{
+			name:     "buffer past the queue",
+			policy:   watch.PolicyBuffer,
+			changes:  5,
+			wantSeqs: []int64{1, 2, 3, 4, 5},
+		},
+		{
+			name:    "buffer past its bound",
+			policy:  watch.PolicyBuffer,
+			changes: 2*64 + 1,
+			wantErr: watch.ErrSlowConsumer,
+		},
+		{
+			name:     "drop within the queue",
+			policy:   watch.PolicyDrop,
+			changes:  2,
+			wantSeqs: []int64{1, 2},
+		},
+		{
+			name:     "drop past the queue",
+			policy:   watch.PolicyDrop,
+			changes:  5,
+			wantSeqs: []int64{1, 2, -3, 5},
+		},
+		{
+			name:     "disconnect within the queue",
+			policy:   watch.PolicyDisconnect,
+			changes:  2,
+			wantSeqs: []int64{1, 2},
+		},
+		{
+			name:    "disconnect past the queue",
+			policy:  watch.PolicyDisconnect,
+			changes: 3,
+			wantErr: watch.ErrSlowConsumer,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hub := watch.NewHub(watch.WithQueue(2))
+			sub, err := hub.Subscribe(watch.Filter{}, 0, tc.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sub.Close()
+
+			index(hub, 1, tc.changes)
+			gotSeqs, err := drain(t, sub)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Next() error = %v, want %v", err, tc.wantErr)
+			}
+			if !slices.Equal(gotSeqs, tc.wantSeqs) {
+				t.Errorf("Next() seqs = %v, want %v", gotSeqs, tc.wantSeqs)
+			}
+		})
+	}
+}
+
+func TestSubscriptionDeliversAfterGap(t *testing.T) {
+	hub := watch.NewHub(watch.WithQueue(2))
+	sub, err := hub.Subscribe(watch.Filter{}, 0, watch.PolicyDrop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	index(hub, 1, 4)
+	if _, err = drain(t, sub); err != nil {
+		t.Fatal(err)
+	}
+
+	// Once caught up, the subscriber gets the changes again.
+	index(hub, 5, 6)
+	gotSeqs, err := drain(t, sub)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if wantSeqs := []int64{5, 6}; !slices.Equal(gotSeqs, wantSeqs) {
+		t.Errorf("Next() seqs = %v, want %v", gotSeqs, wantSeqs)
+	}
+}
+
+func TestSubscribeResumes(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		filter   watch.Filter
+		since    int64
+		wantSeqs []int64
+		wantErr  error
+	}{
+		{
+			name:     "changes to come",
+			since:    0,
+			wantSeqs: []int64{6},
+		},
+		{
+			name:     "oldest in history",
+			since:    2,
+			wantSeqs: []int64{3, 4, 5, 6},
+		},
+		{
+			name:     "latest in history",
+			since:    4,
+			wantSeqs: []int64{5, 6},
+		},
+		{
+			name:     "last change",
+			since:    5,
+			wantSeqs: []int64{6},
+		},
+		{
+			name:     "filtered",
+			filter:   watch.Filter{Tag: "odd"},
+			since:    2,
+			wantSeqs: []int64{3, 5},
+		},
+		{
+			name:    "out of history",
+			since:   1,
+			wantErr: watch.ErrExpired,
+		},
+		{
+			name:    "never reached",
+			since:   6,
+			wantErr: watch.ErrExpired,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hub := watch.NewHub(watch.WithHistory(3))
+			index(hub, 1, 5)
+
+			sub, err := hub.Subscribe(tc.filter, tc.since, watch.PolicyBuffer)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Subscribe() error = %v, want %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer sub.Close()
+
+			index(hub, 6, 6)
+			gotSeqs, err := drain(t, sub)
+			if err != nil {
+				t.Fatalf("Next() error = %v", err)
+			}
+			if !slices.Equal(gotSeqs, tc.wantSeqs) {
+				t.Errorf("Next() seqs = %v, want %v", gotSeqs, tc.wantSeqs)
+			}
+		})
+	}
+}
+
+func TestHubClose(t *testing.T) {
+	hub := watch.NewHub()
+	sub, err := hub.Subscribe(watch.Filter{}, 0, watch.PolicyBuffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hub.Close()
+	if _, err = sub.Next(nil); !errors.Is(err, watch.ErrClosed) {
+		t.Errorf("Next() error = %v, want %v", err, watch.ErrClosed)
+	}
+	if _, err = hub.Subscribe(watch.Filter{}, 0, watch.PolicyBuffer); !errors.Is(err, watch.ErrClosed) {
+		t.Errorf("Subscribe() error = %v, want %v", err, watch.ErrClosed)
+	}
+}